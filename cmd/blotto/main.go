@@ -1,10 +1,13 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/pepperonirollz/cfr/pkg/blotto"
 )
 
 func main() {
 	trainer := blotto.NewBlottoTrainer(10, 4)
+	fmt.Println("num combos", len(trainer.Combinations))
 	trainer.Train(10000)
 }
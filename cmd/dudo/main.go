@@ -0,0 +1,24 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+	"github.com/pepperonirollz/cfr/pkg/dudo"
+)
+
+func main() {
+	iterations := flag.Int("iterations", 10000, "training iterations")
+	external := flag.Bool("external", false, "train with external-sampling MCCFR instead of chance-sampled CFR")
+	seed := flag.Int64("seed", 1, "seed for the dice and external sampling")
+	flag.Parse()
+
+	trainer := dudo.NewDudoTrainer(6, cfr.WithSeed(*seed))
+	if *external {
+		trainer.TrainExternalSampling(*iterations)
+	} else {
+		trainer.Train(*iterations)
+	}
+	trainer.Report(os.Stdout)
+}
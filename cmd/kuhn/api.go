@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pepperonirollz/cfr/pkg/kuhn"
+)
+
+// gameView is the JSON form of a game. The AI's card is never included.
+type gameView struct {
+	ID string `json:"id"`
+	// Deck names the rules the game is played by: full or classic.
+	Deck           string `json:"deck"`
+	HandNumber     int    `json:"handNumber"`
+	State          string `json:"state"`
+	PlayerPosition int    `json:"playerPosition"`
+	PlayerCard     string `json:"playerCard"`
+	PlayerStack    int    `json:"playerStack"`
+	AiStack        int    `json:"aiStack"`
+	Pot            int    `json:"pot"`
+	ActionHistory  string `json:"actionHistory"`
+	// AiDecision is the AI's latest decision point, without its card.
+	AiDecision string            `json:"aiDecision"`
+	Log        string            `json:"log"`
+	Match      kuhn.MatchSummary `json:"match"`
+}
+
+// matchRequest is the optional body of a new game. Zero fields keep the
+// defaults: the full deck, a 10 chip stack, no hand limit and an AI that does
+// not learn during the game.
+type matchRequest struct {
+	Deck  string `json:"deck" form:"deck"`
+	Hands int    `json:"hands" form:"hands"`
+	Stack int    `json:"stack" form:"stack"`
+	Learn bool   `json:"learn" form:"learn"`
+}
+
+func (m matchRequest) options() ([]kuhn.GameOption, error) {
+	if m.Hands < 0 || m.Stack < 0 || m.Stack == 1 {
+		return nil, errors.New("hands must be positive and stack at least 2")
+	}
+	var opts []kuhn.GameOption
+	if m.Hands > 0 {
+		opts = append(opts, kuhn.WithMaxHands(m.Hands))
+	}
+	if m.Stack > 0 {
+		opts = append(opts, kuhn.WithStartingStack(m.Stack))
+	}
+	if m.Learn {
+		opts = append(opts, kuhn.WithOnlineLearning())
+	}
+	return opts, nil
+}
+
+type strategyView struct {
+	InfoSets []kuhn.InfoSetStrategy `json:"infoSets"`
+}
+
+// actionRequest plays Action. When Hand and History are given the action is
+// only taken if the game is still there, so a repeated request cannot act
+// twice.
+type actionRequest struct {
+	Action  string  `json:"action"`
+	Hand    *int    `json:"hand"`
+	History *string `json:"history"`
+}
+
+type apiError struct {
+	Error string `json:"error"`
+	// Code names the game rule that was broken: notYourTurn, gameOver or
+	// noSuchAction.
+	Code string `json:"code,omitempty"`
+}
+
+// gameError maps an error from the game to a response: 409 when the game is
+// not in a state to take the action, 400 when there is no such action.
+func gameError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, kuhn.ErrNotYourTurn):
+		return c.JSON(http.StatusConflict, apiError{Error: err.Error(), Code: "notYourTurn"})
+	case errors.Is(err, kuhn.ErrGameOver):
+		return c.JSON(http.StatusConflict, apiError{Error: err.Error(), Code: "gameOver"})
+	case errors.Is(err, kuhn.ErrNoSuchAction):
+		return c.JSON(http.StatusBadRequest, apiError{Error: err.Error(), Code: "noSuchAction"})
+	case errors.Is(err, kuhn.ErrNoRebuy):
+		return c.JSON(http.StatusConflict, apiError{Error: err.Error()})
+	}
+	return err
+}
+
+// newView must be called with the session's game locked.
+func newView(id string, sess *session) gameView {
+	game := sess.game
+	return gameView{
+		ID:             id,
+		Deck:           sess.deck,
+		HandNumber:     game.HandNumber,
+		State:          game.GameState.String(),
+		PlayerPosition: int(game.PlayerPosition),
+		PlayerCard:     string(game.PlayerCard),
+		PlayerStack:    game.PlayerStack,
+		AiStack:        game.AiStack,
+		Pot:            game.Pot,
+		ActionHistory:  game.ActionHistory,
+		AiDecision:     game.AiDecision(),
+		Log:            game.Log(),
+		Match:          game.Summary(),
+	}
+}
+
+func registerAPI(e *echo.Echo, s *sessions) {
+	e.POST("/api/games", func(c echo.Context) error {
+		var req matchRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, apiError{Error: "body must be {\"deck\": \"classic\", \"hands\": n, \"stack\": n, \"learn\": true} or empty"})
+		}
+		opts, err := req.options()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, apiError{Error: err.Error()})
+		}
+		id, sess, err := s.create(req.Deck, opts...)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, apiError{Error: err.Error()})
+		}
+		game := sess.game
+		game.Lock()
+		defer game.Unlock()
+		return c.JSON(http.StatusCreated, newView(id, sess))
+	})
+	e.GET("/api/games/:id", func(c echo.Context) error {
+		sess, ok := s.get(c.Param("id"))
+		if !ok {
+			return c.JSON(http.StatusNotFound, apiError{Error: "no such game"})
+		}
+		game := sess.game
+		game.Lock()
+		defer game.Unlock()
+		return c.JSON(http.StatusOK, newView(c.Param("id"), sess))
+	})
+	e.DELETE("/api/games/:id", func(c echo.Context) error {
+		if !s.remove(c.Param("id")) {
+			return c.JSON(http.StatusNotFound, apiError{Error: "no such game"})
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.POST("/api/games/:id/rebuy", func(c echo.Context) error {
+		sess, ok := s.get(c.Param("id"))
+		if !ok {
+			return c.JSON(http.StatusNotFound, apiError{Error: "no such game"})
+		}
+		sess.game.Lock()
+		defer sess.game.Unlock()
+		if err := sess.game.Rebuy(); err != nil {
+			return gameError(c, err)
+		}
+		return c.JSON(http.StatusOK, newView(c.Param("id"), sess))
+	})
+	e.POST("/api/games/:id/end", func(c echo.Context) error {
+		sess, ok := s.get(c.Param("id"))
+		if !ok {
+			return c.JSON(http.StatusNotFound, apiError{Error: "no such game"})
+		}
+		sess.game.Lock()
+		defer sess.game.Unlock()
+		return c.JSON(http.StatusOK, sess.game.EndMatch())
+	})
+	e.GET("/api/games/:id/history", func(c echo.Context) error {
+		sess, ok := s.get(c.Param("id"))
+		if !ok {
+			return c.JSON(http.StatusNotFound, apiError{Error: "no such game"})
+		}
+		sess.game.Lock()
+		defer sess.game.Unlock()
+		c.Response().Header().Set(echo.HeaderContentType, "application/x-ndjson")
+		c.Response().WriteHeader(http.StatusOK)
+		return kuhn.WriteJSONL(c.Response(), sess.game.History)
+	})
+	e.GET("/api/games/:id/stats", func(c echo.Context) error {
+		sess, ok := s.get(c.Param("id"))
+		if !ok {
+			return c.JSON(http.StatusNotFound, apiError{Error: "no such game"})
+		}
+		sess.game.Lock()
+		defer sess.game.Unlock()
+		return c.JSON(http.StatusOK, newStatsView(sess.game.Stats()))
+	})
+	e.GET("/api/strategy", func(c echo.Context) error {
+		ai, ok := s.ais[c.QueryParam("deck")]
+		if !ok {
+			return c.JSON(http.StatusNotFound, apiError{Error: "no such deck"})
+		}
+		return c.JSON(http.StatusOK, strategyView{ai.Strategies()})
+	})
+	e.GET("/api/stats", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, newStatsView(s.stats()))
+	})
+	e.GET("/api/games/:id/events", func(c echo.Context) error {
+		sess, ok := s.get(c.Param("id"))
+		if !ok {
+			return c.JSON(http.StatusNotFound, apiError{Error: "no such game"})
+		}
+		return streamEvents(c, sess)
+	})
+	e.POST("/api/games/:id/actions", func(c echo.Context) error {
+		sess, ok := s.get(c.Param("id"))
+		if !ok {
+			return c.JSON(http.StatusNotFound, apiError{Error: "no such game"})
+		}
+		game := sess.game
+		var req actionRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, apiError{Error: "body must be {\"action\": \"check\"} or {\"action\": \"bet\"}"})
+		}
+		action, err := kuhn.ParseAction(req.Action)
+		if err != nil {
+			return gameError(c, err)
+		}
+		game.Lock()
+		defer game.Unlock()
+		if req.Hand != nil || req.History != nil {
+			if req.Hand == nil || req.History == nil {
+				return c.JSON(http.StatusBadRequest, apiError{Error: "hand and history go together"})
+			}
+			if err := game.ExpectTurn(*req.Hand, *req.History); err != nil {
+				return gameError(c, err)
+			}
+		}
+		err = game.Act(action)
+		logHandHistoryErr(c, game)
+		if err != nil {
+			return gameError(c, err)
+		}
+		return c.JSON(http.StatusOK, newView(c.Param("id"), sess))
+	})
+}
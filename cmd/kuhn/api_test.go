@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pepperonirollz/cfr/pkg/kuhn"
+)
+
+func newTestSessions() *sessions {
+	ai := kuhn.NewKuhnTrainer(kuhn.WithSeed(1))
+	ai.Train(10000)
+	return newSessions(map[string]kuhn.KuhnTrainer{fullDeck: ai, classicDeck: classicAi()})
+}
+
+func newTestServer() *echo.Echo {
+	return newServer(newTestSessions())
+}
+
+func do(e *echo.Echo, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) gameView {
+	t.Helper()
+	var view gameView
+	if err := json.Unmarshal(rec.Body.Bytes(), &view); err != nil {
+		t.Fatalf("decoding %q: %v", rec.Body.String(), err)
+	}
+	return view
+}
+
+func TestAPIPlaysSeparateGames(t *testing.T) {
+	e := newTestServer()
+	// Deep stacks, so no one goes bust in twenty actions.
+	rec := do(e, http.MethodPost, "/api/games", `{"stack": 100}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status %d", rec.Code)
+	}
+	a := decode(t, rec)
+	b := decode(t, do(e, http.MethodPost, "/api/games", `{"stack": 100}`))
+	if a.ID == "" || a.ID == b.ID {
+		t.Fatalf("game IDs %q and %q", a.ID, b.ID)
+	}
+
+	for i := 0; i < 20; i++ {
+		action := `{"action": "check"}`
+		if i%3 == 0 {
+			action = `{"action": "bet"}`
+		}
+		rec := do(e, http.MethodPost, "/api/games/"+a.ID+"/actions", action)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("action %d: status %d: %s", i, rec.Code, rec.Body.String())
+		}
+		view := decode(t, rec)
+		if total := view.PlayerStack + view.AiStack + view.Pot; total != 200 {
+			t.Fatalf("after action %d the chips add up to %d", i, total)
+		}
+	}
+
+	got := decode(t, do(e, http.MethodGet, "/api/games/"+a.ID, ""))
+	if got.HandNumber == 1 {
+		t.Error("twenty actions did not finish a hand")
+	}
+	if other := decode(t, do(e, http.MethodGet, "/api/games/"+b.ID, "")); other.HandNumber != 1 || other.ActionHistory != b.ActionHistory {
+		t.Errorf("playing one game changed the other: %+v", other)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	e := newTestServer()
+	id := decode(t, do(e, http.MethodPost, "/api/games", "")).ID
+	tests := []struct {
+		method, path, body string
+		want               int
+	}{
+		{http.MethodGet, "/api/games/missing", "", http.StatusNotFound},
+		{http.MethodPost, "/api/games/missing/actions", `{"action": "check"}`, http.StatusNotFound},
+		{http.MethodPost, "/api/games/" + id + "/actions", `{"action": "raise"}`, http.StatusBadRequest},
+		{http.MethodPost, "/api/games/" + id + "/actions", `not json`, http.StatusBadRequest},
+		{http.MethodPost, "/api/games", `{"stack": 1}`, http.StatusBadRequest},
+		{http.MethodPost, "/api/games", `{"hands": -1}`, http.StatusBadRequest},
+		{http.MethodPost, "/api/games/" + id + "/rebuy", "", http.StatusConflict},
+		{http.MethodPost, "/api/games/" + id + "/end", "", http.StatusOK},
+		{http.MethodDelete, "/api/games/" + id, "", http.StatusNoContent},
+		{http.MethodGet, "/api/games/" + id, "", http.StatusNotFound},
+		// The page used to dereference a nil game here.
+		{http.MethodPost, "/pass", "", http.StatusBadRequest},
+		{http.MethodPost, "/bet", "", http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		if rec := do(e, tc.method, tc.path, tc.body); rec.Code != tc.want {
+			t.Errorf("%s %s: status %d, want %d", tc.method, tc.path, rec.Code, tc.want)
+		}
+	}
+}
+
+func TestAPIHistory(t *testing.T) {
+	e := newTestServer()
+	id := decode(t, do(e, http.MethodPost, "/api/games", "")).ID
+	for i := 0; i < 10; i++ {
+		do(e, http.MethodPost, "/api/games/"+id+"/actions", `{"action": "check"}`)
+	}
+	view := decode(t, do(e, http.MethodGet, "/api/games/"+id, ""))
+	rec := do(e, http.MethodGet, "/api/games/"+id+"/history", "")
+	hands, err := kuhn.ReadJSONL(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hands) != view.HandNumber-1 {
+		t.Errorf("history has %d hands, %d were finished", len(hands), view.HandNumber-1)
+	}
+}
+
+func TestAPIStats(t *testing.T) {
+	e := newTestServer()
+	for _, id := range []string{
+		decode(t, do(e, http.MethodPost, "/api/games", "")).ID,
+		decode(t, do(e, http.MethodPost, "/api/games", "")).ID,
+	} {
+		for i := 0; i < 10; i++ {
+			do(e, http.MethodPost, "/api/games/"+id+"/actions", `{"action": "bet"}`)
+		}
+		var session statsView
+		json.Unmarshal(do(e, http.MethodGet, "/api/games/"+id+"/stats", "").Body.Bytes(), &session)
+		view := decode(t, do(e, http.MethodGet, "/api/games/"+id, ""))
+		if session.Hands != view.Match.Hands || session.Winnings != view.Match.PlayerNet {
+			t.Errorf("session stats %+v do not match the game %+v", session, view)
+		}
+	}
+	var cumulative statsView
+	json.Unmarshal(do(e, http.MethodGet, "/api/stats", "").Body.Bytes(), &cumulative)
+	if cumulative.Hands < 2 || len(cumulative.ConfidenceInterval) != 2 {
+		t.Errorf("cumulative stats %+v", cumulative)
+	}
+}
+
+func TestAPIStrategy(t *testing.T) {
+	e := newTestServer()
+	var body strategyView
+	if err := json.Unmarshal(do(e, http.MethodGet, "/api/strategy", "").Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	// 13 cards at 4 decision points.
+	if len(body.InfoSets) != 52 {
+		t.Errorf("%d information sets, want 52", len(body.InfoSets))
+	}
+}
+
+func TestAPIRuleErrors(t *testing.T) {
+	e := newTestServer()
+	view := decode(t, do(e, http.MethodPost, "/api/games", `{"stack": 100}`))
+	path := "/api/games/" + view.ID + "/actions"
+	body := fmt.Sprintf(`{"action": "check", "hand": %d, "history": %q}`, view.HandNumber, view.ActionHistory)
+
+	if rec := do(e, http.MethodPost, path, body); rec.Code != http.StatusOK {
+		t.Fatalf("first submit: status %d: %s", rec.Code, rec.Body.String())
+	}
+	tests := []struct {
+		name, path, body string
+		status           int
+		code             string
+	}{
+		{"double submit", path, body, http.StatusConflict, "notYourTurn"},
+		{"unknown action", path, `{"action": "raise"}`, http.StatusBadRequest, "noSuchAction"},
+		{"half a turn", path, `{"action": "bet", "hand": 1}`, http.StatusBadRequest, ""},
+		{"end", "/api/games/" + view.ID + "/end", "", http.StatusOK, ""},
+		{"act after the end", path, `{"action": "bet"}`, http.StatusConflict, "gameOver"},
+	}
+	for _, tc := range tests {
+		rec := do(e, http.MethodPost, tc.path, tc.body)
+		var got apiError
+		json.Unmarshal(rec.Body.Bytes(), &got)
+		if rec.Code != tc.status || got.Code != tc.code {
+			t.Errorf("%s: status %d code %q, want %d %q", tc.name, rec.Code, got.Code, tc.status, tc.code)
+		}
+	}
+}
+
+func TestAPIClassicDeck(t *testing.T) {
+	e := newTestServer()
+	rec := do(e, http.MethodPost, "/api/games", `{"deck": "classic", "stack": 100}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", rec.Code, rec.Body.String())
+	}
+	view := decode(t, rec)
+	for i := 0; i < 20; i++ {
+		if view.Deck != classicDeck || !strings.Contains("JQK", view.PlayerCard) {
+			t.Fatalf("deck %q dealt %q", view.Deck, view.PlayerCard)
+		}
+		view = decode(t, do(e, http.MethodPost, "/api/games/"+view.ID+"/actions", `{"action": "check"}`))
+	}
+	if full := decode(t, do(e, http.MethodPost, "/api/games", "")); full.Deck != fullDeck {
+		t.Errorf("default deck %q, want %q", full.Deck, fullDeck)
+	}
+	if rec := do(e, http.MethodPost, "/api/games", `{"deck": "pinochle"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown deck: status %d", rec.Code)
+	}
+
+	var body strategyView
+	if err := json.Unmarshal(do(e, http.MethodGet, "/api/strategy?deck=classic", "").Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.InfoSets) != 12 {
+		t.Errorf("%d classic information sets, want 12", len(body.InfoSets))
+	}
+	if rec := do(e, http.MethodGet, "/api/strategy?deck=pinochle", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("strategy for an unknown deck: status %d", rec.Code)
+	}
+}
+
+func TestAPILearningGame(t *testing.T) {
+	e := newTestServer()
+	before := do(e, http.MethodGet, "/api/strategy", "").Body.String()
+	view := decode(t, do(e, http.MethodPost, "/api/games", `{"stack": 100, "learn": true}`))
+	for i := 0; i < 30; i++ {
+		do(e, http.MethodPost, "/api/games/"+view.ID+"/actions", `{"action": "bet"}`)
+	}
+	if view = decode(t, do(e, http.MethodGet, "/api/games/"+view.ID, "")); view.Match.Hands == 0 {
+		t.Fatal("no hands were finished")
+	}
+	if after := do(e, http.MethodGet, "/api/strategy", "").Body.String(); after != before {
+		t.Error("a learning game changed the server's AI")
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pepperonirollz/cfr/pkg/kuhn"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it
+// is disconnected, so a slow spectator never holds up the game.
+const subscriberBuffer = 256
+
+// hub fans one game's events out to every subscriber.
+type hub struct {
+	sync.Mutex
+	subscribers map[chan kuhn.Event]struct{}
+	closed      bool
+}
+
+func newHub() *hub {
+	return &hub{subscribers: make(map[chan kuhn.Event]struct{})}
+}
+
+func (h *hub) publish(e kuhn.Event) {
+	h.Lock()
+	defer h.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- e:
+		default:
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// subscribe returns a channel of the events published from now on. It is
+// closed when the subscriber falls too far behind or the game ends.
+func (h *hub) subscribe() chan kuhn.Event {
+	h.Lock()
+	defer h.Unlock()
+	ch := make(chan kuhn.Event, subscriberBuffer)
+	if h.closed {
+		close(ch)
+		return ch
+	}
+	h.subscribers[ch] = struct{}{}
+	return ch
+}
+
+func (h *hub) unsubscribe(ch chan kuhn.Event) {
+	h.Lock()
+	defer h.Unlock()
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (h *hub) close() {
+	h.Lock()
+	defer h.Unlock()
+	for ch := range h.subscribers {
+		close(ch)
+	}
+	h.subscribers = nil
+	h.closed = true
+}
+
+// streamEvents sends the session's events to the client as server-sent
+// events, named by event type, until either side goes away.
+func streamEvents(c echo.Context, sess *session) error {
+	events := sess.events.subscribe()
+	defer sess.events.unsubscribe(events)
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data); err != nil {
+				return nil
+			}
+			w.Flush()
+		}
+	}
+}
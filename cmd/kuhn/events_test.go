@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pepperonirollz/cfr/pkg/kuhn"
+)
+
+func TestEventStream(t *testing.T) {
+	server := httptest.NewServer(newTestServer())
+	defer server.Close()
+
+	res, err := http.Post(server.URL+"/api/games", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var view gameView
+	json.NewDecoder(res.Body).Decode(&view)
+	res.Body.Close()
+
+	stream, err := http.Get(server.URL + "/api/games/" + view.ID + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Body.Close()
+	if got := stream.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("Content-Type %q", got)
+	}
+
+	events := make(chan kuhn.Event)
+	go func() {
+		scanner := bufio.NewScanner(stream.Body)
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				var e kuhn.Event
+				json.Unmarshal([]byte(data), &e)
+				events <- e
+			}
+		}
+		close(events)
+	}()
+
+	res, err = http.Post(server.URL+"/api/games/"+view.ID+"/actions", "application/json", strings.NewReader(`{"action": "bet"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	select {
+	case e := <-events:
+		if e.Type != kuhn.ActionEvent || e.Actor != kuhn.PlayerActor || e.Action != "bet" || e.Amount != 1 {
+			t.Errorf("first event %+v, want the player's bet", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event within 5s")
+	}
+}
@@ -1,15 +1,27 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"html/template"
 	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/pepperonirollz/cfr/pkg/kuhn"
+	"github.com/pepperonirollz/cfr/pkg/snapshot"
 )
 
-var game *kuhn.Game
+// gameCookie holds the ID of the browser's game in sessions.
+const gameCookie = "kuhn_game"
 
 type Templates struct {
 	templates *template.Template
@@ -21,14 +33,89 @@ func (t *Templates) Render(w io.Writer, name string, data interface{}, c echo.Co
 
 func newTemplate() *Templates {
 	return &Templates{
-		templates: template.Must(template.ParseGlob("../../templates/*.html")),
+		templates: template.Must(template.New("").Funcs(template.FuncMap{
+			"percent": func(f float64) string { return fmt.Sprintf("%.0f%%", 100*f) },
+		}).ParseGlob("../../templates/*.html")),
 	}
 }
 
+// loadAi reads the AI's strategy for the full deck from path, or trains one
+// and saves it there when the file does not exist yet. An empty path always
+// trains.
+func loadAi(path string, workers int) kuhn.KuhnTrainer {
+	ai := kuhn.NewKuhnTrainer(kuhn.WithWorkers(workers))
+	if path == "" {
+		ai.Train(100000)
+		ai.Report(os.Stdout)
+		return ai
+	}
+	err := ai.Load(path)
+	if err == nil {
+		return ai
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		log.Fatal(err)
+	}
+	ai.Train(100000)
+	ai.Report(os.Stdout)
+	format := snapshot.JSON
+	if filepath.Ext(path) == ".bin" {
+		format = snapshot.Binary
+	}
+	if err := ai.Save(path, format); err != nil {
+		log.Fatal(err)
+	}
+	return ai
+}
+
+// classicAi solves J, Q, K Kuhn, which takes CFR+ a fraction of a second.
+func classicAi() kuhn.KuhnTrainer {
+	ai, err := kuhn.NewKuhnTrainerForConfig(kuhn.ClassicConfig(), kuhn.WithVariant(kuhn.CFRPlus))
+	if err != nil {
+		log.Fatal(err)
+	}
+	ai.Train(10000)
+	return ai
+}
+
 func main() {
+	strategy := flag.String("strategy", "", "snapshot to load the AI from, trained and written first if missing (.bin for binary, otherwise JSON)")
+	workers := flag.Int("workers", 1, "goroutines to train the AI with")
+	history := flag.String("history", "", "file to append every finished hand to as JSON Lines")
+	idle := flag.Duration("idle", 30*time.Minute, "end games nobody has played for this long, 0 to keep them forever")
+	flag.Parse()
+	ais := map[string]kuhn.KuhnTrainer{
+		fullDeck:    loadAi(*strategy, *workers),
+		classicDeck: classicAi(),
+	}
 
-	e := echo.New()
+	var opts []kuhn.GameOption
+	if *history != "" {
+		file, err := os.OpenFile(*history, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		opts = append(opts, kuhn.WithHandHistory(&lockedWriter{w: file}))
+	}
+	s := newSessions(ais, opts...)
+	if *history != "" {
+		if err := s.loadStats(*history); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *idle > 0 {
+		go s.expireEvery(*idle)
+	}
+
+	e := newServer(s)
 	e.Use(middleware.Logger())
+	e.Logger.Fatal(e.Start(":8080"))
+}
+
+func newServer(s *sessions) *echo.Echo {
+	e := echo.New()
 	e.Renderer = newTemplate()
 	e.Static("/static", "../../static")
 
@@ -37,18 +124,102 @@ func main() {
 	})
 
 	e.POST("/start", func(c echo.Context) error {
-		game = kuhn.NewGame()
-		game.BeginRound()
-		return c.Render(200, "dashboard", game)
+		var req matchRequest
+		if err := c.Bind(&req); err != nil {
+			return c.String(http.StatusBadRequest, "hands and stack must be numbers")
+		}
+		opts, err := req.options()
+		if err != nil {
+			return c.String(http.StatusBadRequest, err.Error())
+		}
+		id, sess, err := s.create(req.Deck, opts...)
+		if err != nil {
+			return c.String(http.StatusBadRequest, err.Error())
+		}
+		// the new game replaces the one the browser was playing
+		if cookie, err := c.Cookie(gameCookie); err == nil {
+			s.remove(cookie.Value)
+		}
+		c.SetCookie(&http.Cookie{Name: gameCookie, Value: id, Path: "/", HttpOnly: true})
+		sess.game.Lock()
+		defer sess.game.Unlock()
+		return c.Render(200, "dashboard", newDashboard(s, sess))
 	})
 	e.POST("/pass", func(c echo.Context) error {
-		game.Check()
-		return c.Render(200, "dashboard", game)
+		return playTurnFromCookie(c, s, (*kuhn.Game).Check)
 	})
 	e.POST("/bet", func(c echo.Context) error {
-		game.Bet()
-		return c.Render(200, "dashboard", game)
+		return playTurnFromCookie(c, s, (*kuhn.Game).Bet)
 	})
-	e.Logger.Fatal(e.Start(":8080"))
+	e.POST("/rebuy", func(c echo.Context) error {
+		return playFromCookie(c, s, (*kuhn.Game).Rebuy)
+	})
+	e.POST("/end", func(c echo.Context) error {
+		return playFromCookie(c, s, func(g *kuhn.Game) error {
+			g.EndMatch()
+			return nil
+		})
+	})
+	e.GET("/events", func(c echo.Context) error {
+		sess, ok := sessionFromCookie(c, s)
+		if !ok {
+			return c.String(http.StatusBadRequest, "no game in progress, start one first")
+		}
+		return streamEvents(c, sess)
+	})
+	registerAPI(e, s)
+	return e
+}
+
+// playFromCookie plays action in the browser's game and renders the
+// dashboard.
+func playFromCookie(c echo.Context, s *sessions, action func(*kuhn.Game) error) error {
+	sess, ok := sessionFromCookie(c, s)
+	if !ok {
+		return c.String(http.StatusBadRequest, "no game in progress, start one first")
+	}
+	game := sess.game
+	game.Lock()
+	defer game.Unlock()
+	err := action(game)
+	logHandHistoryErr(c, game)
+	if err != nil {
+		return c.String(http.StatusConflict, err.Error())
+	}
+	return c.Render(200, "dashboard", newDashboard(s, sess))
+}
 
+// playTurnFromCookie is playFromCookie for a check or a bet. When the form
+// sends the hand and history the page showed, the action is only taken if
+// the game is still there, so a double click cannot act twice.
+func playTurnFromCookie(c echo.Context, s *sessions, action func(*kuhn.Game) error) error {
+	if c.FormValue("hand") == "" {
+		return playFromCookie(c, s, action)
+	}
+	hand, err := strconv.Atoi(c.FormValue("hand"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, "hand must be a number")
+	}
+	history := c.FormValue("history")
+	return playFromCookie(c, s, func(g *kuhn.Game) error {
+		if err := g.ExpectTurn(hand, history); err != nil {
+			return err
+		}
+		return action(g)
+	})
+}
+
+// logHandHistoryErr logs a hand the game could not write to -history.
+func logHandHistoryErr(c echo.Context, game *kuhn.Game) {
+	if err := game.HandHistoryErr(); err != nil {
+		c.Logger().Errorf("writing hand history: %v", err)
+	}
+}
+
+func sessionFromCookie(c echo.Context, s *sessions) (*session, bool) {
+	cookie, err := c.Cookie(gameCookie)
+	if err != nil {
+		return nil, false
+	}
+	return s.get(cookie.Value)
 }
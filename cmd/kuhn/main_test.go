@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// start posts the start form, sending cookie when it is not nil, and returns
+// the cookie of the new game.
+func start(t *testing.T, e *echo.Echo, cookie *http.Cookie) *http.Cookie {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/start", strings.NewReader("deck=full"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("start: status %d: %s", rec.Code, rec.Body.String())
+	}
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == gameCookie {
+			return c
+		}
+	}
+	t.Fatal("start set no game cookie")
+	return nil
+}
+
+// post posts form to path as the browser holding cookie.
+func post(e *echo.Echo, path string, form url.Values, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPageDoubleClick(t *testing.T) {
+	s := newTestSessions()
+	e := newServer(s)
+	cookie := start(t, e, nil)
+	game := s.games[cookie.Value].game
+	turn := url.Values{
+		"hand":    {strconv.Itoa(game.HandNumber)},
+		"history": {game.ActionHistory},
+	}
+
+	rec := post(e, "/bet", turn, cookie)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("first click: status %d: %s", rec.Code, rec.Body.String())
+	}
+	hand, history, stack := game.HandNumber, game.ActionHistory, game.PlayerStack
+	if rec := post(e, "/bet", turn, cookie); rec.Code != http.StatusConflict {
+		t.Errorf("second click: status %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if game.HandNumber != hand || game.ActionHistory != history || game.PlayerStack != stack {
+		t.Errorf("the second click acted: hand %d %q with %d chips, was hand %d %q with %d",
+			game.HandNumber, game.ActionHistory, game.PlayerStack, hand, history, stack)
+	}
+
+	// the page sends the turn it shows with every button
+	want := `hx-vals='{"hand": ` + strconv.Itoa(hand) + `, "history": "` + history + `"}'`
+	if !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("the dashboard does not send the turn it shows, want %s in\n%s", want, rec.Body.String())
+	}
+}
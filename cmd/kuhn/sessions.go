@@ -0,0 +1,197 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pepperonirollz/cfr/pkg/kuhn"
+)
+
+// sessions holds every game in progress by ID. Each game has its own lock,
+// which callers hold while they play or read it.
+type sessions struct {
+	sync.Mutex
+	games map[string]*session
+	// ais holds an AI for each deck a game can be played with.
+	ais  map[string]kuhn.KuhnTrainer
+	opts []kuhn.GameOption
+	// cumulative holds the results of every hand finished on the server.
+	cumulative kuhn.Stats
+}
+
+type session struct {
+	game   *kuhn.Game
+	events *hub
+	deck   string
+	// lastUsed is when the session was created or last looked up. It is
+	// guarded by the sessions lock.
+	lastUsed time.Time
+}
+
+// The decks a game can be played with. The full deck is the default, and
+// the AI for it is also stored under "".
+const (
+	fullDeck    = "full"
+	classicDeck = "classic"
+)
+
+// newSessions plays every game against the AI for its deck, created with
+// opts.
+func newSessions(ais map[string]kuhn.KuhnTrainer, opts ...kuhn.GameOption) *sessions {
+	s := &sessions{
+		games: make(map[string]*session),
+		ais:   make(map[string]kuhn.KuhnTrainer),
+		opts:  opts,
+	}
+	for deck, ai := range ais {
+		s.ais[deck] = ai
+	}
+	s.ais[""] = s.ais[fullDeck]
+	return s
+}
+
+// create starts a game with deck against its AI and deals the first hand.
+// The options are applied after the server's own.
+func (s *sessions) create(deck string, matchOpts ...kuhn.GameOption) (string, *session, error) {
+	ai, ok := s.ais[deck]
+	if !ok {
+		return "", nil, fmt.Errorf("no such deck %q, choose %s or %s", deck, fullDeck, classicDeck)
+	}
+	if deck == "" {
+		deck = fullDeck
+	}
+	id := newID()
+	events := newHub()
+	opts := append([]kuhn.GameOption{kuhn.WithEvents(events.publish), kuhn.WithHandListener(s.record)}, s.opts...)
+	opts = append(opts, matchOpts...)
+	game, err := kuhn.NewGameWithAi(ai, opts...)
+	if err != nil {
+		return "", nil, err
+	}
+	sess := &session{
+		game:     game,
+		events:   events,
+		deck:     deck,
+		lastUsed: time.Now(),
+	}
+	if err := sess.game.BeginRound(); err != nil {
+		return "", nil, err
+	}
+	s.Lock()
+	s.games[id] = sess
+	s.Unlock()
+	return id, sess, nil
+}
+
+func (s *sessions) get(id string) (*session, bool) {
+	s.Lock()
+	defer s.Unlock()
+	sess, ok := s.games[id]
+	if ok {
+		sess.lastUsed = time.Now()
+	}
+	return sess, ok
+}
+
+func (s *sessions) record(h kuhn.HandRecord) {
+	s.Lock()
+	defer s.Unlock()
+	s.cumulative.Add(h)
+}
+
+func (s *sessions) stats() kuhn.Stats {
+	s.Lock()
+	defer s.Unlock()
+	stats := s.cumulative
+	stats.Actions = make([]kuhn.ActionFrequency, len(s.cumulative.Actions))
+	for i, f := range s.cumulative.Actions {
+		counts := make(map[string]int, len(f.Counts))
+		for action, n := range f.Counts {
+			counts[action] = n
+		}
+		f.Counts = counts
+		stats.Actions[i] = f
+	}
+	return stats
+}
+
+// remove ends the game and disconnects everyone watching it.
+func (s *sessions) remove(id string) bool {
+	s.Lock()
+	sess, ok := s.games[id]
+	delete(s.games, id)
+	s.Unlock()
+	if ok {
+		sess.events.close()
+	}
+	return ok
+}
+
+// expireIdle ends every game not used since before, disconnecting everyone
+// watching it, and returns how many it ended.
+func (s *sessions) expireIdle(before time.Time) int {
+	var expired []*session
+	s.Lock()
+	for id, sess := range s.games {
+		if sess.lastUsed.Before(before) {
+			expired = append(expired, sess)
+			delete(s.games, id)
+		}
+	}
+	s.Unlock()
+	for _, sess := range expired {
+		sess.events.close()
+	}
+	return len(expired)
+}
+
+// expireEvery ends games that have been idle for longer than idle, checking
+// twice per idle period for as long as the server runs.
+func (s *sessions) expireEvery(idle time.Duration) {
+	for range time.Tick(idle / 2) {
+		s.expireIdle(time.Now().Add(-idle))
+	}
+}
+
+// lockedWriter lets every game append its hands to the same file.
+type lockedWriter struct {
+	sync.Mutex
+	w io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.Lock()
+	defer l.Unlock()
+	return l.w.Write(p)
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// loadStats adds the hands in a history file to the cumulative stats, so
+// they survive a restart.
+func (s *sessions) loadStats(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	hands, err := kuhn.ReadJSONL(file)
+	if err != nil {
+		return err
+	}
+	for _, h := range hands {
+		s.record(h)
+	}
+	return nil
+}
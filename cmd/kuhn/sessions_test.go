@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartReplacesTheBrowsersGame(t *testing.T) {
+	s := newTestSessions()
+	e := newServer(s)
+	first := start(t, e, nil)
+	firstEvents := s.games[first.Value].events.subscribe()
+	second := start(t, e, first)
+
+	if _, ok := s.get(first.Value); ok {
+		t.Error("the first game is still there after starting another")
+	}
+	if _, ok := s.get(second.Value); !ok || len(s.games) != 1 {
+		t.Errorf("%d games after starting twice, want only the second", len(s.games))
+	}
+	if _, open := <-firstEvents; open {
+		t.Error("the first game's event stream is still open")
+	}
+}
+
+func TestExpireIdle(t *testing.T) {
+	s := newTestSessions()
+	idleID, idle, _ := s.create("")
+	activeID, _, _ := s.create("")
+	events := idle.events.subscribe()
+	s.Lock()
+	idle.lastUsed = time.Now().Add(-time.Hour)
+	s.Unlock()
+
+	if n := s.expireIdle(time.Now().Add(-time.Minute)); n != 1 {
+		t.Errorf("expired %d games, want 1", n)
+	}
+	if _, ok := s.get(idleID); ok {
+		t.Error("the idle game was kept")
+	}
+	if _, ok := s.get(activeID); !ok {
+		t.Error("the active game was expired")
+	}
+	if _, open := <-events; open {
+		t.Error("the idle game's event stream is still open")
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"github.com/pepperonirollz/cfr/pkg/kuhn"
+)
+
+// statsView is the JSON and template form of kuhn.Stats.
+type statsView struct {
+	Hands    int     `json:"hands"`
+	Winnings int     `json:"winnings"`
+	WinRate  float64 `json:"winRate"`
+	StdDev   float64 `json:"stdDev"`
+	// ConfidenceInterval is the 95% interval of WinRate, left out until
+	// there are two hands.
+	ConfidenceInterval  []float64             `json:"confidenceInterval,omitempty"`
+	Showdowns           int                   `json:"showdowns"`
+	ShowdownWinnings    int                   `json:"showdownWinnings"`
+	NonShowdownWinnings int                   `json:"nonShowdownWinnings"`
+	Actions             []actionFrequencyView `json:"actions"`
+}
+
+type actionFrequencyView struct {
+	kuhn.ActionFrequency
+	Frequencies map[string]float64 `json:"frequencies"`
+}
+
+func newStatsView(s kuhn.Stats) statsView {
+	view := statsView{
+		Hands:               s.Hands,
+		Winnings:            s.Winnings,
+		WinRate:             s.WinRate(),
+		StdDev:              s.StdDev(),
+		Showdowns:           s.Showdowns,
+		ShowdownWinnings:    s.ShowdownWinnings,
+		NonShowdownWinnings: s.NonShowdownWinnings,
+		Actions:             []actionFrequencyView{},
+	}
+	if s.Hands >= 2 {
+		low, high := s.ConfidenceInterval()
+		view.ConfidenceInterval = []float64{low, high}
+	}
+	for _, f := range s.Actions {
+		frequencies := make(map[string]float64, len(f.Counts))
+		for action := range f.Counts {
+			frequencies[action] = f.Frequency(action)
+		}
+		view.Actions = append(view.Actions, actionFrequencyView{f, frequencies})
+	}
+	return view
+}
+
+// dashboard is what the dashboard template renders: the game and the
+// player's results in it and across every game on the server.
+type dashboard struct {
+	*kuhn.Game
+	DeckName   string
+	Session    statsView
+	Cumulative statsView
+}
+
+// newDashboard must be called with the session's game locked.
+func newDashboard(s *sessions, sess *session) dashboard {
+	return dashboard{
+		Game:       sess.game,
+		DeckName:   sess.deck,
+		Session:    newStatsView(sess.game.Stats()),
+		Cumulative: newStatsView(s.stats()),
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/pepperonirollz/cfr/pkg/leduc"
+)
+
+func main() {
+	iterations := flag.Int("iterations", 2000, "training iterations")
+	flag.Parse()
+
+	trainer := leduc.NewLeducTrainer()
+	trainer.Train(*iterations)
+	trainer.Report(os.Stdout)
+}
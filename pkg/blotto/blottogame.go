@@ -0,0 +1,75 @@
+package blotto
+
+import (
+	"strconv"
+
+	"github.com/pepperonirollz/cfr/pkg/game"
+)
+
+// BlottoGame is Colonel Blotto as a game.Game. Like the rps port, the second
+// player's information set hides the first allocation, making the sequential
+// tree equivalent to the simultaneous game.
+type BlottoGame struct {
+	S            int
+	N            int
+	Combinations [][]int
+}
+
+type blottoState struct {
+	combinations [][]int
+	actions      []int
+}
+
+// s = soldiers, n = numBattlefields
+func NewBlottoGame(s, n int) BlottoGame {
+	var combos [][]int
+	generateCombinations([]int{}, s, n, 0, &combos)
+	return BlottoGame{
+		S:            s,
+		N:            n,
+		Combinations: combos,
+	}
+}
+
+func (g BlottoGame) NumPlayers() int {
+	return 2
+}
+
+func (g BlottoGame) Root() game.State {
+	return blottoState{combinations: g.Combinations}
+}
+
+func (s blottoState) IsTerminal() bool {
+	return len(s.actions) == 2
+}
+
+func (s blottoState) CurrentPlayer() int {
+	return len(s.actions)
+}
+
+func (s blottoState) LegalActions() []int {
+	actions := make([]int, len(s.combinations))
+	for a := range actions {
+		actions[a] = a
+	}
+	return actions
+}
+
+func (s blottoState) ChanceOutcomes() []game.Outcome {
+	return nil
+}
+
+func (s blottoState) Apply(action int) game.State {
+	return blottoState{
+		combinations: s.combinations,
+		actions:      append(append([]int(nil), s.actions...), action),
+	}
+}
+
+func (s blottoState) Utility(player int) float64 {
+	return float64(evaluateWinner(s.combinations[s.actions[player]], s.combinations[s.actions[1-player]]))
+}
+
+func (s blottoState) InfoSetKey() string {
+	return strconv.Itoa(s.CurrentPlayer())
+}
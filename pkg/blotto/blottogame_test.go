@@ -0,0 +1,28 @@
+package blotto
+
+import (
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+)
+
+func TestBlottoGameEquilibrium(t *testing.T) {
+	g := NewBlottoGame(5, 3)
+	trainer := cfr.NewTrainer(g)
+	trainer.Train(2000)
+
+	// Against an equilibrium of this symmetric game no pure allocation can
+	// win on average.
+	for player, infoSet := range []string{"0", "1"} {
+		strategy := trainer.AvgStrategy(infoSet, len(g.Combinations))
+		for i, mine := range g.Combinations {
+			util := 0.0
+			for j, theirs := range g.Combinations {
+				util += strategy[j] * float64(evaluateWinner(mine, theirs))
+			}
+			if util > 0.02 {
+				t.Errorf("%v beats player %d's average strategy by %.3f", g.Combinations[i], player, util)
+			}
+		}
+	}
+}
@@ -4,39 +4,57 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"time"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
 )
 
 type BlottoTrainer struct {
+	cfr.Node
 	S            int
 	N            int
 	Combinations [][]int
 	NumActions   int
-	Strategy     []float64
-	StrategySum  []float64
-	RegretSum    []float64
 	OppStrategy  []float64
+	rng          *rand.Rand
+}
+
+type Option func(*BlottoTrainer)
+
+// WithSeed fixes the allocations Train samples for both sides.
+func WithSeed(seed int64) Option {
+	return WithRand(rand.New(rand.NewSource(seed)))
+}
+
+// WithRand makes Train sample both sides' allocations from r.
+func WithRand(r *rand.Rand) Option {
+	return func(t *BlottoTrainer) {
+		t.rng = r
+	}
 }
 
-func NewBlottoTrainer(s, n int) *BlottoTrainer {
+func NewBlottoTrainer(s, n int, opts ...Option) *BlottoTrainer {
 	var combos [][]int
 	generateCombinations([]int{}, s, n, 0, &combos)
-	fmt.Println("num combos", len(combos))
 	opp := make([]float64, len(combos))
 
-	return &BlottoTrainer{
+	t := &BlottoTrainer{
+		Node:         *cfr.NewNode("", len(combos)),
 		S:            s,
 		N:            n,
 		Combinations: combos,
 		NumActions:   len(combos),
-		Strategy:     make([]float64, len(combos)),
-		StrategySum:  make([]float64, len(combos)),
-		RegretSum:    make([]float64, len(combos)),
 		OppStrategy:  opp,
+		rng:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(t)
 	}
+	return t
 }
 
 func (t *BlottoTrainer) getAction(strategy []float64) int {
-	r := rand.Float64()
+	r := t.rng.Float64()
 	a := 0
 	var cumulativeProbability float64 = 0
 	for a < t.NumActions-1 {
@@ -49,25 +67,6 @@ func (t *BlottoTrainer) getAction(strategy []float64) int {
 	return a
 }
 
-func (t *BlottoTrainer) getAverageStrategy() []float64 {
-	avgStrategy := make([]float64, t.NumActions)
-	var normalizingSum float64
-
-	for _, sum := range t.StrategySum {
-		normalizingSum += sum
-	}
-
-	for a, sum := range t.StrategySum {
-		if normalizingSum > 0 {
-			avgStrategy[a] = sum / normalizingSum
-		} else {
-			avgStrategy[a] = 1.0 / float64(t.NumActions)
-		}
-	}
-
-	return avgStrategy
-}
-
 func (t *BlottoTrainer) Train(iterations int) {
 	actionUtility := make([]float64, t.NumActions)
 
@@ -91,25 +90,8 @@ func (t *BlottoTrainer) Train(iterations int) {
 }
 
 func (t *BlottoTrainer) getStrategy() []float64 {
-	normalizingSum := 0.0
-	for i := 0; i < t.NumActions; i++ {
-		if t.RegretSum[i] > 0 {
-			t.Strategy[i] = t.RegretSum[i]
-		} else {
-			t.Strategy[i] = 0
-		}
-		normalizingSum += t.Strategy[i]
-	}
-
-	for i := 0; i < t.NumActions; i++ {
-		if normalizingSum > 0 {
-			t.Strategy[i] /= normalizingSum
-		} else {
-			t.Strategy[i] = 1.0 / float64(t.NumActions)
-		}
-		t.StrategySum[i] += t.Strategy[i]
-
-	}
+	t.RegretMatching()
+	t.AccumulateStrategy(1)
 	return t.Strategy
 }
 
@@ -162,7 +144,7 @@ func generateCombinations(combination []int, s, n, start int, results *[][]int)
 func (t *BlottoTrainer) getBestStrategy() []int {
 	max := math.SmallestNonzeroFloat64
 	index := -1
-	avgStrat := t.getAverageStrategy()
+	avgStrat := t.GetAvgStrategy()
 	for i, probability := range avgStrat {
 		if probability > max {
 			max = probability
@@ -172,3 +154,12 @@ func (t *BlottoTrainer) getBestStrategy() []int {
 	fmt.Println(max, "---", t.Combinations[index])
 	return t.Combinations[index]
 }
+
+// Exploitability is what a best response wins per game against the average
+// strategy, averaged over both seats.
+func (t *BlottoTrainer) Exploitability() float64 {
+	g := BlottoGame{S: t.S, N: t.N, Combinations: t.Combinations}
+	return cfr.Exploitability(g, func(string, int) []float64 {
+		return t.GetAvgStrategy()
+	})
+}
@@ -0,0 +1,42 @@
+package blotto
+
+import "testing"
+
+// TestTrainAvoidsStackedFields checks that with 5 soldiers on 3 fields the
+// average strategy gives up on putting 4 or more soldiers on one field,
+// which wins that field and loses the other two.
+func TestTrainAvoidsStackedFields(t *testing.T) {
+	trainer := NewBlottoTrainer(5, 3, WithSeed(1))
+	trainer.Train(20000)
+	avg := trainer.GetAvgStrategy()
+	for i, combo := range trainer.Combinations {
+		for _, soldiers := range combo {
+			if soldiers >= 4 && avg[i] >= 0.01 {
+				t.Errorf("%v is played with probability %.3f", combo, avg[i])
+			}
+		}
+	}
+}
+
+// BenchmarkTrain times an iteration with 10 soldiers on 4 battlefields,
+// where every one of the 286 pure strategies is scored against the
+// opponent's action.
+func BenchmarkTrain(b *testing.B) {
+	trainer := NewBlottoTrainer(10, 4, WithSeed(1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	trainer.Train(b.N)
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "iterations/s")
+}
+
+// BenchmarkConvergence trains a new trainer for a fixed budget per op and
+// reports the exploitability it reaches.
+func BenchmarkConvergence(b *testing.B) {
+	var exploitability float64
+	for i := 0; i < b.N; i++ {
+		trainer := NewBlottoTrainer(10, 4, WithSeed(1))
+		trainer.Train(10000)
+		exploitability = trainer.Exploitability()
+	}
+	b.ReportMetric(exploitability, "exploitability")
+}
@@ -0,0 +1,39 @@
+package blotto
+
+import (
+	"fmt"
+
+	"github.com/pepperonirollz/cfr/pkg/snapshot"
+)
+
+const snapshotGame = "blotto"
+
+// Snapshot stores the trainer's sums as a single node, since blotto has only
+// the one decision, along with the soldiers and battlefields it was for.
+func (t *BlottoTrainer) Snapshot() *snapshot.Snapshot {
+	return snapshot.OneNode(snapshotGame, t.snapshotConfig(), t.SnapshotNode())
+}
+
+func (t *BlottoTrainer) Restore(s *snapshot.Snapshot) error {
+	n, err := s.OneNode(snapshotGame, t.snapshotConfig())
+	if err != nil {
+		return err
+	}
+	return t.RestoreNode(n)
+}
+
+func (t *BlottoTrainer) snapshotConfig() string {
+	return fmt.Sprintf("soldiers %d battlefields %d", t.S, t.N)
+}
+
+func (t *BlottoTrainer) Save(path string, f snapshot.Format) error {
+	return snapshot.Save(path, t.Snapshot(), f)
+}
+
+func (t *BlottoTrainer) Load(path string) error {
+	s, err := snapshot.Load(path)
+	if err != nil {
+		return err
+	}
+	return t.Restore(s)
+}
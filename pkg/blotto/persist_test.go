@@ -0,0 +1,40 @@
+package blotto
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/snapshot"
+)
+
+func TestSaveLoad(t *testing.T) {
+	trainer := NewBlottoTrainer(5, 3, WithSeed(1))
+	trainer.Train(1000)
+
+	for _, f := range []snapshot.Format{snapshot.JSON, snapshot.Binary} {
+		path := filepath.Join(t.TempDir(), "blotto")
+		if err := trainer.Save(path, f); err != nil {
+			t.Fatal(err)
+		}
+		loaded := NewBlottoTrainer(5, 3)
+		if err := loaded.Load(path); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded.Snapshot(), trainer.Snapshot()) {
+			t.Errorf("format %d: loaded trainer differs from the saved one", f)
+		}
+	}
+}
+
+// TestLoadRejectsAnotherGame loads 3 soldiers on 2 battlefields into a
+// trainer for 1 soldier on 4, which has the same 4 pure strategies.
+func TestLoadRejectsAnotherGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blotto")
+	if err := NewBlottoTrainer(3, 2).Save(path, snapshot.JSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewBlottoTrainer(1, 4).Load(path); err == nil {
+		t.Error("loaded a snapshot for 3 soldiers on 2 battlefields into 1 soldier on 4")
+	}
+}
@@ -0,0 +1,137 @@
+package cfr
+
+import (
+	"math"
+
+	"github.com/pepperonirollz/cfr/pkg/game"
+)
+
+// Policy returns the probability of each of the numActions legal actions at
+// an information set. Trainer.AvgStrategy is one.
+type Policy func(infoSet string, numActions int) []float64
+
+// Exploitability is NashConv divided by the number of players: what a player
+// gains on average by switching to a best response while the others keep to
+// policy. In a two-player zero-sum game it is the average of both best
+// responses' values, and zero exactly at a Nash equilibrium.
+func Exploitability(g game.Game, policy Policy) float64 {
+	return NashConv(g, policy) / float64(g.NumPlayers())
+}
+
+// NashConv sums over players what each would gain by switching from policy
+// to a best response.
+func NashConv(g game.Game, policy Policy) float64 {
+	values := policyValue(g.Root(), g.NumPlayers(), policy)
+	total := 0.0
+	for player, value := range values {
+		total += BestResponseValue(g, player, policy) - value
+	}
+	return total
+}
+
+// BestResponseValue is what player wins by playing a best response while
+// everyone else plays policy. The game must have perfect recall.
+func BestResponseValue(g game.Game, player int, policy Policy) float64 {
+	br := &bestResponse{
+		player:   player,
+		policy:   policy,
+		infoSets: make(map[string][]reached),
+		actions:  make(map[string]int),
+	}
+	br.collect(g.Root(), 1)
+	return br.value(g.Root())
+}
+
+// Exploitability is the exploitability of the average strategy in NodeMap.
+func (t *Trainer) Exploitability() float64 {
+	return Exploitability(t.Game, t.AvgStrategy)
+}
+
+type bestResponse struct {
+	player int
+	policy Policy
+	// infoSets holds every state where player acts, by information set.
+	infoSets map[string][]reached
+	// actions is the index of the best legal action at each information
+	// set, once it is known.
+	actions map[string]int
+}
+
+// reached is a state and the probability that chance and the other players
+// play to it.
+type reached struct {
+	state game.State
+	reach float64
+}
+
+func (b *bestResponse) collect(s game.State, reach float64) {
+	if s.IsTerminal() {
+		return
+	}
+	if s.CurrentPlayer() == game.Chance {
+		for _, outcome := range s.ChanceOutcomes() {
+			b.collect(s.Apply(outcome.Action), reach*outcome.Prob)
+		}
+		return
+	}
+
+	actions := s.LegalActions()
+	if s.CurrentPlayer() == b.player {
+		key := s.InfoSetKey()
+		b.infoSets[key] = append(b.infoSets[key], reached{s, reach})
+		for _, action := range actions {
+			b.collect(s.Apply(action), reach)
+		}
+		return
+	}
+	strategy := b.policy(s.InfoSetKey(), len(actions))
+	for i, action := range actions {
+		b.collect(s.Apply(action), reach*strategy[i])
+	}
+}
+
+// value is what the best responder wins from s, given that s was reached.
+func (b *bestResponse) value(s game.State) float64 {
+	if s.IsTerminal() {
+		return s.Utility(b.player)
+	}
+	value := 0.0
+	if s.CurrentPlayer() == game.Chance {
+		for _, outcome := range s.ChanceOutcomes() {
+			value += outcome.Prob * b.value(s.Apply(outcome.Action))
+		}
+		return value
+	}
+
+	actions := s.LegalActions()
+	if s.CurrentPlayer() == b.player {
+		return b.value(s.Apply(actions[b.bestAction(s.InfoSetKey())]))
+	}
+	strategy := b.policy(s.InfoSetKey(), len(actions))
+	for i, action := range actions {
+		value += strategy[i] * b.value(s.Apply(action))
+	}
+	return value
+}
+
+// bestAction picks the action that wins the most summed over every state of
+// infoSet, weighted by how likely each is. With perfect recall that only
+// depends on the choices below it, which are picked the same way.
+func (b *bestResponse) bestAction(infoSet string) int {
+	if a, ok := b.actions[infoSet]; ok {
+		return a
+	}
+	states := b.infoSets[infoSet]
+	best, bestValue := 0, math.Inf(-1)
+	for i, action := range states[0].state.LegalActions() {
+		value := 0.0
+		for _, r := range states {
+			value += r.reach * b.value(r.state.Apply(action))
+		}
+		if value > bestValue {
+			best, bestValue = i, value
+		}
+	}
+	b.actions[infoSet] = best
+	return best
+}
@@ -0,0 +1,195 @@
+// Package cfr implements counterfactual regret minimization against any game
+// that satisfies game.Game.
+package cfr
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/pepperonirollz/cfr/pkg/game"
+)
+
+type Trainer struct {
+	Game      game.Game
+	NodeMap   map[string]*Node
+	iteration int
+	rng       *rand.Rand
+}
+
+type Option func(*Trainer)
+
+// WithSeed seeds the chance outcomes and actions TrainChanceSampling and
+// TrainExternalSampling sample. Train samples nothing, so it does not need
+// one.
+func WithSeed(seed int64) Option {
+	return WithRand(rand.New(rand.NewSource(seed)))
+}
+
+// WithRand makes the sampling methods draw from r. A rand.Rand is not safe
+// for concurrent use, so r needs to be used by this trainer alone.
+func WithRand(r *rand.Rand) Option {
+	return func(t *Trainer) {
+		t.rng = r
+	}
+}
+
+func NewTrainer(g game.Game, opts ...Option) *Trainer {
+	t := &Trainer{
+		Game:    g,
+		NodeMap: make(map[string]*Node),
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Train runs vanilla CFR, enumerating every chance outcome, and returns the
+// average root utility for each player across the iterations.
+func (t *Trainer) Train(iterations int) []float64 {
+	return t.train(iterations, false)
+}
+
+// TrainChanceSampling runs chance-sampled CFR: each iteration draws one
+// outcome at every chance node and walks every action of the players below
+// it. It returns the average sampled root utility for each player.
+func (t *Trainer) TrainChanceSampling(iterations int) []float64 {
+	return t.train(iterations, true)
+}
+
+func (t *Trainer) train(iterations int, sampleChance bool) []float64 {
+	numPlayers := t.Game.NumPlayers()
+	util := make([]float64, numPlayers)
+	for i := 0; i < iterations; i++ {
+		reach := make([]float64, numPlayers)
+		for p := range reach {
+			reach[p] = 1
+		}
+		value := t.cfr(t.Game.Root(), reach, 1, sampleChance)
+		for p := range util {
+			util[p] += value[p]
+		}
+		t.iteration++
+	}
+	for p := range util {
+		util[p] /= float64(iterations)
+	}
+	return util
+}
+
+// cfr returns every player's value of s. chance is the probability chance
+// plays to s, or 1 when sampleChance draws a single outcome instead.
+func (t *Trainer) cfr(s game.State, reach []float64, chance float64, sampleChance bool) []float64 {
+	numPlayers := len(reach)
+	if s.IsTerminal() {
+		return utilities(s, numPlayers)
+	}
+
+	if s.CurrentPlayer() == game.Chance && sampleChance {
+		return t.cfr(s.Apply(t.sampleOutcome(s.ChanceOutcomes())), reach, chance, sampleChance)
+	}
+	if s.CurrentPlayer() == game.Chance {
+		nodeUtil := make([]float64, numPlayers)
+		for _, outcome := range s.ChanceOutcomes() {
+			util := t.cfr(s.Apply(outcome.Action), reach, chance*outcome.Prob, sampleChance)
+			for p := range nodeUtil {
+				nodeUtil[p] += outcome.Prob * util[p]
+			}
+		}
+		return nodeUtil
+	}
+
+	player := s.CurrentPlayer()
+	actions := s.LegalActions()
+	node := t.getOrCreateNode(s.InfoSetKey(), len(actions))
+	strategy := node.getStrategy(t.iteration)
+
+	util := make([][]float64, len(actions))
+	nodeUtil := make([]float64, numPlayers)
+	for i, action := range actions {
+		nextReach := append([]float64(nil), reach...)
+		nextReach[player] *= strategy[i]
+		util[i] = t.cfr(s.Apply(action), nextReach, chance, sampleChance)
+		for p := range nodeUtil {
+			nodeUtil[p] += strategy[i] * util[i][p]
+		}
+	}
+
+	counterfactualReach := chance
+	for p, r := range reach {
+		if p != player {
+			counterfactualReach *= r
+		}
+	}
+	for i := range actions {
+		node.RegretSum[i] += counterfactualReach * (util[i][player] - nodeUtil[player])
+		node.StrategySum[i] += reach[player] * strategy[i]
+	}
+	return nodeUtil
+}
+
+// Value returns the expected utility of each player when everyone plays the
+// average strategy accumulated so far.
+func (t *Trainer) Value() []float64 {
+	return policyValue(t.Game.Root(), t.Game.NumPlayers(), t.AvgStrategy)
+}
+
+// policyValue is the expected utility of each player from s when everyone
+// plays policy.
+func policyValue(s game.State, numPlayers int, policy Policy) []float64 {
+	if s.IsTerminal() {
+		return utilities(s, numPlayers)
+	}
+
+	nodeUtil := make([]float64, numPlayers)
+	if s.CurrentPlayer() == game.Chance {
+		for _, outcome := range s.ChanceOutcomes() {
+			util := policyValue(s.Apply(outcome.Action), numPlayers, policy)
+			for p := range nodeUtil {
+				nodeUtil[p] += outcome.Prob * util[p]
+			}
+		}
+		return nodeUtil
+	}
+
+	actions := s.LegalActions()
+	strategy := policy(s.InfoSetKey(), len(actions))
+	for i, action := range actions {
+		util := policyValue(s.Apply(action), numPlayers, policy)
+		for p := range nodeUtil {
+			nodeUtil[p] += strategy[i] * util[p]
+		}
+	}
+	return nodeUtil
+}
+
+// AvgStrategy returns the average strategy at infoSet, or uniform if the
+// information set was never visited.
+func (t *Trainer) AvgStrategy(infoSet string, numActions int) []float64 {
+	if node, ok := t.NodeMap[infoSet]; ok {
+		return node.GetAvgStrategy()
+	}
+	strategy := make([]float64, numActions)
+	for i := range strategy {
+		strategy[i] = 1.0 / float64(numActions)
+	}
+	return strategy
+}
+
+func (t *Trainer) getOrCreateNode(infoSet string, numActions int) *Node {
+	node, ok := t.NodeMap[infoSet]
+	if !ok {
+		node = NewNode(infoSet, numActions)
+		t.NodeMap[infoSet] = node
+	}
+	return node
+}
+
+func utilities(s game.State, numPlayers int) []float64 {
+	util := make([]float64, numPlayers)
+	for p := range util {
+		util[p] = s.Utility(p)
+	}
+	return util
+}
@@ -0,0 +1,60 @@
+package cfr_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+	"github.com/pepperonirollz/cfr/pkg/kuhn"
+)
+
+func sameNodes(a, b map[string]*cfr.Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, node := range a {
+		other, ok := b[key]
+		if !ok {
+			return false
+		}
+		for i := range node.RegretSum {
+			if math.Float64bits(node.RegretSum[i]) != math.Float64bits(other.RegretSum[i]) ||
+				math.Float64bits(node.StrategySum[i]) != math.Float64bits(other.StrategySum[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSameSeedSameNodes(t *testing.T) {
+	methods := map[string]func(*cfr.Trainer, int) []float64{
+		"chance":   (*cfr.Trainer).TrainChanceSampling,
+		"external": (*cfr.Trainer).TrainExternalSampling,
+	}
+	for name, train := range methods {
+		t.Run(name, func(t *testing.T) {
+			run := func(seed int64) map[string]*cfr.Node {
+				trainer := cfr.NewTrainer(kuhn.NewKuhnGame([]rune{'J', 'Q', 'K'}), cfr.WithSeed(seed))
+				train(trainer, 2000)
+				return trainer.NodeMap
+			}
+			a := run(3)
+			if !sameNodes(a, run(3)) {
+				t.Error("two runs with the same seed produced different nodes")
+			}
+			if sameNodes(a, run(4)) {
+				t.Error("runs with different seeds produced identical nodes")
+			}
+		})
+	}
+}
+
+func TestExploitabilityFalls(t *testing.T) {
+	trainer := cfr.NewTrainer(kuhn.NewKuhnGame([]rune{'J', 'Q', 'K'}))
+	before := trainer.Exploitability()
+	trainer.Train(1000)
+	if after := trainer.Exploitability(); after >= before/2 {
+		t.Errorf("exploitability went from %.3f to %.3f after 1000 iterations", before, after)
+	}
+}
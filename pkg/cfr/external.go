@@ -0,0 +1,88 @@
+package cfr
+
+import "github.com/pepperonirollz/cfr/pkg/game"
+
+// TrainExternalSampling runs external-sampling Monte Carlo CFR. Each
+// iteration picks the next player in turn as the traverser, samples chance
+// and every other player's actions from their current strategy, and explores
+// all of the traverser's actions. It returns the traverser's average sampled
+// utility for each player.
+func (t *Trainer) TrainExternalSampling(iterations int) []float64 {
+	numPlayers := t.Game.NumPlayers()
+	util := make([]float64, numPlayers)
+	counts := make([]int, numPlayers)
+	for i := 0; i < iterations; i++ {
+		traverser := t.iteration % numPlayers
+		util[traverser] += t.externalSampling(t.Game.Root(), traverser, numPlayers)
+		counts[traverser]++
+		t.iteration++
+	}
+	for p := range util {
+		if counts[p] > 0 {
+			util[p] /= float64(counts[p])
+		}
+	}
+	return util
+}
+
+func (t *Trainer) externalSampling(s game.State, traverser int, numPlayers int) float64 {
+	if s.IsTerminal() {
+		return s.Utility(traverser)
+	}
+
+	if s.CurrentPlayer() == game.Chance {
+		return t.externalSampling(s.Apply(t.sampleOutcome(s.ChanceOutcomes())), traverser, numPlayers)
+	}
+
+	player := s.CurrentPlayer()
+	actions := s.LegalActions()
+	node := t.getOrCreateNode(s.InfoSetKey(), len(actions))
+	strategy := node.getStrategy(t.iteration)
+
+	if player != traverser {
+		// The opponent's reach is sampled, so adding the current strategy
+		// unweighted keeps the average unbiased. Only the player after the
+		// traverser does it, so each iteration counts once.
+		if player == (traverser+1)%numPlayers {
+			for i := range actions {
+				node.StrategySum[i] += strategy[i]
+			}
+		}
+		return t.externalSampling(s.Apply(actions[t.sampleAction(strategy)]), traverser, numPlayers)
+	}
+
+	util := make([]float64, len(actions))
+	nodeUtil := 0.0
+	for i, action := range actions {
+		util[i] = t.externalSampling(s.Apply(action), traverser, numPlayers)
+		nodeUtil += strategy[i] * util[i]
+	}
+	for i := range actions {
+		node.RegretSum[i] += util[i] - nodeUtil
+	}
+	return nodeUtil
+}
+
+func (t *Trainer) sampleAction(strategy []float64) int {
+	r := t.rng.Float64()
+	cumulativeProbability := 0.0
+	for i, p := range strategy {
+		cumulativeProbability += p
+		if r < cumulativeProbability {
+			return i
+		}
+	}
+	return len(strategy) - 1
+}
+
+func (t *Trainer) sampleOutcome(outcomes []game.Outcome) int {
+	r := t.rng.Float64()
+	cumulativeProbability := 0.0
+	for _, outcome := range outcomes {
+		cumulativeProbability += outcome.Prob
+		if r < cumulativeProbability {
+			return outcome.Action
+		}
+	}
+	return outcomes[len(outcomes)-1].Action
+}
@@ -0,0 +1,121 @@
+package cfr
+
+import (
+	"fmt"
+
+	"github.com/pepperonirollz/cfr/pkg/snapshot"
+)
+
+// Node holds one information set's regret and strategy sums. Every trainer
+// in the repository keeps its sums in Nodes, whichever way it walks the
+// game.
+type Node struct {
+	InfoSet   string
+	RegretSum []float64
+	// Strategy is the current strategy, as last set by RegretMatching.
+	Strategy    []float64
+	StrategySum []float64
+	// iteration the cached strategy was computed for, so every history in
+	// an information set plays the same strategy within one iteration.
+	iteration int
+}
+
+func NewNode(infoSet string, numActions int) *Node {
+	n := &Node{
+		InfoSet:     infoSet,
+		RegretSum:   make([]float64, numActions),
+		Strategy:    make([]float64, numActions),
+		StrategySum: make([]float64, numActions),
+		iteration:   -1,
+	}
+	for i := range n.Strategy {
+		n.Strategy[i] = 1.0 / float64(numActions)
+	}
+	return n
+}
+
+func (n *Node) NumActions() int {
+	return len(n.RegretSum)
+}
+
+// RegretMatching sets Strategy proportional to the positive regrets, or
+// uniform when no regret is positive.
+func (n *Node) RegretMatching() {
+	normalizingSum := 0.0
+	for i, regret := range n.RegretSum {
+		if regret > 0 {
+			n.Strategy[i] = regret
+		} else {
+			n.Strategy[i] = 0
+		}
+		normalizingSum += n.Strategy[i]
+	}
+
+	for i := range n.Strategy {
+		if normalizingSum > 0 {
+			n.Strategy[i] /= normalizingSum
+		} else {
+			n.Strategy[i] = 1.0 / float64(n.NumActions())
+		}
+	}
+}
+
+// AccumulateStrategy adds Strategy, weighted by realizationWeight, to
+// StrategySum.
+func (n *Node) AccumulateStrategy(realizationWeight float64) {
+	for i, p := range n.Strategy {
+		n.StrategySum[i] += realizationWeight * p
+	}
+}
+
+// getStrategy runs RegretMatching once per iteration.
+func (n *Node) getStrategy(iteration int) []float64 {
+	if n.iteration != iteration {
+		n.iteration = iteration
+		n.RegretMatching()
+	}
+	return n.Strategy
+}
+
+// GetAvgStrategy is StrategySum normalized, or uniform before anything has
+// been accumulated.
+func (n *Node) GetAvgStrategy() []float64 {
+	avgStrategy := make([]float64, n.NumActions())
+	var normalizingSum float64
+	for _, sum := range n.StrategySum {
+		normalizingSum += sum
+	}
+	for i, sum := range n.StrategySum {
+		if normalizingSum > 0 {
+			avgStrategy[i] = sum / normalizingSum
+		} else {
+			avgStrategy[i] = 1.0 / float64(n.NumActions())
+		}
+	}
+	return avgStrategy
+}
+
+func (n Node) String() string {
+	return fmt.Sprintf("%4s: %v", n.InfoSet, n.GetAvgStrategy())
+}
+
+// SnapshotNode copies the node's sums for a snapshot.
+func (n *Node) SnapshotNode() snapshot.Node {
+	return snapshot.Node{
+		InfoSet:     n.InfoSet,
+		RegretSum:   append([]float64(nil), n.RegretSum...),
+		StrategySum: append([]float64(nil), n.StrategySum...),
+	}
+}
+
+// RestoreNode copies the sums of s into the node, which must have the same
+// number of actions.
+func (n *Node) RestoreNode(s snapshot.Node) error {
+	if len(s.RegretSum) != n.NumActions() || len(s.StrategySum) != n.NumActions() {
+		return fmt.Errorf("cfr: snapshot node %q does not have %d actions", s.InfoSet, n.NumActions())
+	}
+	n.InfoSet = s.InfoSet
+	copy(n.RegretSum, s.RegretSum)
+	copy(n.StrategySum, s.StrategySum)
+	return nil
+}
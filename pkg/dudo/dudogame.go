@@ -0,0 +1,97 @@
+package dudo
+
+import (
+	"strconv"
+
+	"github.com/pepperonirollz/cfr/pkg/game"
+)
+
+// DudoGame is 1-die-each Dudo as a game.Game, which DudoTrainer and the
+// sampling trainers in pkg/cfr run on. Information set keys are
+// infoSetToInteger in decimal.
+type DudoGame struct {
+	rules
+}
+
+type dudoState struct {
+	rules *rules
+	dice  []int
+	// claimed has bit a set once claim a has been made.
+	claimed   int
+	plays     int
+	lastClaim int
+	called    bool
+}
+
+func NewDudoGame(sides int) *DudoGame {
+	return &DudoGame{rules: newRules(sides)}
+}
+
+func (g *DudoGame) NumPlayers() int {
+	return 2
+}
+
+func (g *DudoGame) Root() game.State {
+	return dudoState{rules: &g.rules, lastClaim: -1}
+}
+
+func (s dudoState) IsTerminal() bool {
+	return s.called
+}
+
+func (s dudoState) CurrentPlayer() int {
+	if len(s.dice) < 2 {
+		return game.Chance
+	}
+	return s.plays % 2
+}
+
+func (s dudoState) LegalActions() []int {
+	return s.rules.legalActions(s.lastClaim)
+}
+
+func (s dudoState) ChanceOutcomes() []game.Outcome {
+	outcomes := make([]game.Outcome, s.rules.numSides)
+	for i := range outcomes {
+		outcomes[i] = game.Outcome{Action: i + 1, Prob: 1.0 / float64(s.rules.numSides)}
+	}
+	return outcomes
+}
+
+func (s dudoState) Apply(action int) game.State {
+	next := s
+	switch {
+	case s.CurrentPlayer() == game.Chance:
+		next.dice = append(append([]int(nil), s.dice...), action)
+	case action == s.rules.dudo:
+		next.called = true
+	default:
+		next.claimed |= 1 << action
+		next.plays, next.lastClaim = s.plays+1, action
+	}
+	return next
+}
+
+func (s dudoState) Utility(player int) float64 {
+	payoff := s.rules.terminalStatePayoff(s.dice, s.lastClaim)
+	// dudo is not counted in plays, so plays%2 is the player who called it
+	if player == s.plays%2 {
+		return -payoff
+	}
+	return payoff
+}
+
+func (s dudoState) InfoSetKey() string {
+	player := s.CurrentPlayer()
+	return strconv.Itoa(s.rules.infoSetToInteger(s.dice[player], s.claimed))
+}
+
+// InfoSetString renders an information set key as the roll followed by the
+// claims made so far.
+func (g *DudoGame) InfoSetString(infoSet string) string {
+	infoSetNum, err := strconv.Atoi(infoSet)
+	if err != nil {
+		return infoSet
+	}
+	return g.infoSetToString(infoSetNum)
+}
@@ -0,0 +1,55 @@
+package dudo
+
+// Thus, a Dudo information set consists of (1) a history of claims
+// from the current round, (2) the player’s private roll information, and (3) the number
+// of opponent dice
+//
+// This trainer plays the 1-die-each game, so the number of opponent dice is
+// always one and whoever loses the first challenge loses the game.
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+)
+
+// DudoTrainer runs chance-sampled CFR on DudoGame with the generic engine:
+// every iteration rolls one pair of dice and walks every claim from there.
+type DudoTrainer struct {
+	*cfr.Trainer
+	game *DudoGame
+}
+
+func NewDudoTrainer(sides int, opts ...cfr.Option) DudoTrainer {
+	g := NewDudoGame(sides)
+	return DudoTrainer{Trainer: cfr.NewTrainer(g, opts...), game: g}
+}
+
+// Train runs chance-sampled CFR and returns each player's average sampled
+// value.
+func (d DudoTrainer) Train(iterations int) []float64 {
+	return d.TrainChanceSampling(iterations)
+}
+
+// Report writes each player's value, the number of information sets and
+// every information set in key order to w. Each strategy is indexed by the
+// legal actions at that point: the claims above the last one, followed by
+// dudo once a claim has been made.
+func (d DudoTrainer) Report(w io.Writer) {
+	value := d.Value()
+	fmt.Fprintln(w, "Expected value: ", value[0], "player 2: ", value[1])
+	fmt.Fprintln(w, "Num infosets: ", len(d.NodeMap))
+	keys := make([]int, 0, len(d.NodeMap))
+	for infoSet := range d.NodeMap {
+		key, _ := strconv.Atoi(infoSet)
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	for _, key := range keys {
+		infoSet := strconv.Itoa(key)
+		fmt.Fprintf(w, "%s: %v\n", d.game.InfoSetString(infoSet), d.NodeMap[infoSet].GetAvgStrategy())
+	}
+}
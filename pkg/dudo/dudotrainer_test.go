@@ -0,0 +1,38 @@
+package dudo
+
+import (
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+)
+
+// BenchmarkTrain times an iteration of chance-sampled CFR against one of
+// external sampling on the six-sided game.
+func BenchmarkTrain(b *testing.B) {
+	b.Run("chance", func(b *testing.B) {
+		trainer := NewDudoTrainer(6, cfr.WithSeed(1))
+		b.ReportAllocs()
+		b.ResetTimer()
+		trainer.Train(b.N)
+		b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "iterations/s")
+	})
+	b.Run("external", func(b *testing.B) {
+		trainer := NewDudoTrainer(6, cfr.WithSeed(1))
+		b.ReportAllocs()
+		b.ResetTimer()
+		trainer.TrainExternalSampling(b.N)
+		b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "iterations/s")
+	})
+}
+
+// BenchmarkConvergence trains a new trainer for a fixed budget per op and
+// reports the exploitability it reaches.
+func BenchmarkConvergence(b *testing.B) {
+	var exploitability float64
+	for i := 0; i < b.N; i++ {
+		trainer := NewDudoTrainer(6, cfr.WithSeed(1))
+		trainer.Train(1000)
+		exploitability = trainer.Exploitability()
+	}
+	b.ReportMetric(exploitability, "exploitability")
+}
@@ -0,0 +1,94 @@
+package dudo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rules holds DudoGame's claim ordering.
+type rules struct {
+	numSides   int
+	numActions int
+	dudo       int
+	claimNum   []int
+	claimRank  []int
+}
+
+func newRules(sides int) rules {
+	numActions := sides*2 + 1
+	claimNum := make([]int, 0, numActions-1)
+	claimRank := make([]int, 0, numActions-1)
+	for num := 1; num <= 2; num++ {
+		// ones are wild, so a claim of ones outranks every other claim of
+		// the same number
+		for rank := 2; rank <= sides; rank++ {
+			claimNum = append(claimNum, num)
+			claimRank = append(claimRank, rank)
+		}
+		claimNum = append(claimNum, num)
+		claimRank = append(claimRank, 1)
+	}
+	return rules{
+		numSides:   sides,
+		numActions: numActions,
+		claimNum:   claimNum,
+		claimRank:  claimRank,
+		dudo:       numActions - 1,
+	}
+}
+
+// terminalStatePayoff is from the point of view of the player to act after
+// dudo was called, which is always the player who made the challenged claim.
+func (r *rules) terminalStatePayoff(dice []int, lastClaim int) float64 {
+	if r.countRank(dice, r.claimRank[lastClaim]) >= r.claimNum[lastClaim] {
+		return 1
+	}
+	return -1
+}
+
+func (r *rules) countRank(dice []int, rank int) int {
+	count := 0
+	for _, die := range dice {
+		if die == rank || die == 1 {
+			count++
+		}
+	}
+	return count
+}
+
+// legalActions lists every claim above lastClaim, then dudo if anything has
+// been claimed yet.
+func (r *rules) legalActions(lastClaim int) []int {
+	var actions []int
+	for a := lastClaim + 1; a < r.dudo; a++ {
+		actions = append(actions, a)
+	}
+	if lastClaim >= 0 {
+		actions = append(actions, r.dudo)
+	}
+	return actions
+}
+
+// claimHistoryToString lists the claims set in claimed, lowest first.
+func (r *rules) claimHistoryToString(claimed int) string {
+	var sb strings.Builder
+	for a := 0; a < r.dudo; a++ {
+		if claimed&(1<<a) != 0 {
+			if sb.Len() > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(fmt.Sprintf("%d*%d", r.claimNum[a], r.claimRank[a]))
+		}
+	}
+	return sb.String()
+}
+
+// infoSetToInteger packs the roll above one bit per claim.
+func (r *rules) infoSetToInteger(playerRoll int, claimed int) int {
+	return playerRoll<<r.dudo | claimed
+}
+
+// infoSetToString reverses infoSetToInteger into the form Report prints.
+func (r *rules) infoSetToString(infoSetNum int) string {
+	return fmt.Sprintf("%d [%s]", infoSetNum>>r.dudo, r.claimHistoryToString(infoSetNum))
+}
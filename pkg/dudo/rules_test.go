@@ -0,0 +1,91 @@
+package dudo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLegalActions(t *testing.T) {
+	r := newRules(6)
+	tests := []struct {
+		name      string
+		lastClaim int
+		want      []int
+	}{
+		{"opening", -1, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+		{"after 1*2", 0, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+		{"after 1*1", 5, []int{6, 7, 8, 9, 10, 11, 12}},
+		{"after 2*6", 10, []int{11, 12}},
+		{"after 2*1", 11, []int{12}},
+	}
+	for _, tt := range tests {
+		if got := r.legalActions(tt.lastClaim); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: legal actions %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClaimOrder(t *testing.T) {
+	r := newRules(6)
+	tests := []struct {
+		action    int
+		num, rank int
+	}{
+		{0, 1, 2},
+		{4, 1, 6},
+		// ones are wild, so they outrank every other claim of the same number
+		{5, 1, 1},
+		{6, 2, 2},
+		{11, 2, 1},
+	}
+	for _, tt := range tests {
+		if r.claimNum[tt.action] != tt.num || r.claimRank[tt.action] != tt.rank {
+			t.Errorf("claim %d is %d*%d, want %d*%d", tt.action,
+				r.claimNum[tt.action], r.claimRank[tt.action], tt.num, tt.rank)
+		}
+	}
+	if r.dudo != 12 {
+		t.Errorf("dudo is action %d, want 12", r.dudo)
+	}
+}
+
+// TestDudoPayoff calls dudo on a single claim and checks both players'
+// utilities: the claimant wins when the dice, counting ones as wild, hold at
+// least the claimed number of the claimed rank.
+func TestDudoPayoff(t *testing.T) {
+	g := NewDudoGame(6)
+	tests := []struct {
+		dice  []int
+		claim int
+		want  float64
+	}{
+		{[]int{3, 5}, 1, 1},   // 1*3, one three
+		{[]int{2, 5}, 1, -1},  // 1*3, no threes
+		{[]int{1, 5}, 1, 1},   // 1*3, a wild one
+		{[]int{1, 5}, 9, 1},   // 2*5, a five and a wild one
+		{[]int{2, 3}, 8, -1},  // 2*4, no fours
+		{[]int{4, 4}, 8, 1},   // 2*4, two fours
+		{[]int{1, 1}, 11, 1},  // 2*1, two ones
+		{[]int{1, 6}, 11, -1}, // 2*1, one one
+		{[]int{4, 6}, 5, -1},  // 1*1, no ones
+	}
+	for _, tt := range tests {
+		s := g.Root()
+		for _, die := range tt.dice {
+			s = s.Apply(die)
+		}
+		s = s.Apply(tt.claim).Apply(g.dudo)
+		if !s.IsTerminal() {
+			t.Fatalf("dice %v, claim %d: dudo did not end the game", tt.dice, tt.claim)
+		}
+		// the first player made the claim and the second called dudo
+		if got := s.Utility(0); got != tt.want {
+			t.Errorf("dice %v, claim %s: claimant wins %v, want %v", tt.dice,
+				g.claimHistoryToString(s.(dudoState).claimed), got, tt.want)
+		}
+		if s.Utility(0)+s.Utility(1) != 0 {
+			t.Errorf("dice %v, claim %d: utilities %v and %v do not sum to zero",
+				tt.dice, tt.claim, s.Utility(0), s.Utility(1))
+		}
+	}
+}
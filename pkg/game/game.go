@@ -0,0 +1,32 @@
+// Package game defines the extensive-form game interface that the generic
+// CFR engine in pkg/cfr runs against.
+package game
+
+// Chance is returned by State.CurrentPlayer when nature is to act.
+const Chance = -1
+
+// Game is a finite extensive-form game.
+type Game interface {
+	NumPlayers() int
+	Root() State
+}
+
+// Outcome is one branch of a chance node and the probability it is taken.
+type Outcome struct {
+	Action int
+	Prob   float64
+}
+
+// State is a node of the game tree. Apply must return a new State and leave
+// the receiver untouched, so the engine can branch on every action.
+type State interface {
+	IsTerminal() bool
+	CurrentPlayer() int
+	LegalActions() []int
+	ChanceOutcomes() []Outcome
+	Apply(action int) State
+	// Utility is only defined for terminal states.
+	Utility(player int) float64
+	// InfoSetKey identifies everything CurrentPlayer knows at this state.
+	InfoSetKey() string
+}
@@ -0,0 +1,83 @@
+package kuhn
+
+import (
+	"strconv"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+)
+
+// Exploitability is what a player gains on average by switching to a best
+// response while everyone else keeps to the average strategy in NodeMap, in
+// milli-chips per hand: cfr.Exploitability of the trainer's Game. With two
+// players it is the average of both best responses' winnings, and it is zero
+// exactly when the average strategy is a Nash equilibrium.
+func (k *KuhnTrainer) Exploitability() float64 {
+	return cfr.Exploitability(k.Game(), func(infoSet string, numActions int) []float64 {
+		return k.avgStrategy(infoSet)
+	}) * 1000
+}
+
+// avgStrategy is the playedStrategy of infoSet, or uniform for information
+// sets never visited.
+func (k *KuhnTrainer) avgStrategy(infoSet string) []float64 {
+	node, ok := k.NodeMap[infoSet]
+	if !ok {
+		node = newKuhnNode(0)
+	}
+	return node.playedStrategy()
+}
+
+// playedStrategy is GetAvgStrategy renormalized after small probabilities
+// are rounded to zero, as the AI plays it.
+func (n *kuhnNode) playedStrategy() []float64 {
+	strategy := n.GetAvgStrategy()
+	normalizingSum := 0.0
+	for _, p := range strategy {
+		normalizingSum += p
+	}
+	for a := range strategy {
+		strategy[a] /= normalizingSum
+	}
+	return strategy
+}
+
+func actionString(a int) string {
+	if Action(a) == Pass {
+		return "p"
+	}
+	return "b"
+}
+
+// Value is what the first player wins per hand when both seats play the
+// average strategy in NodeMap.
+func (k *KuhnTrainer) Value() float64 {
+	n := len(k.config.Deck)
+	cards := make([]rune, 2)
+	value := 0.0
+	for i, c0 := range k.config.Deck {
+		for j, c1 := range k.config.Deck {
+			if i == j {
+				continue
+			}
+			cards[0], cards[1] = c0, c1
+			value += k.profileValue(cards, "")
+		}
+	}
+	return value / float64(n*(n-1))
+}
+
+// profileValue is history's value to the player to act under the average
+// strategy.
+func (k *KuhnTrainer) profileValue(cards []rune, history string) float64 {
+	plays := len(history)
+	player := plays % 2
+	if payoff, ok := k.config.terminalStatePayoff(cards, plays, history, player, 1-player); ok {
+		return float64(payoff)
+	}
+	strategy := k.avgStrategy(strconv.Itoa(player) + " " + string(cards[player]) + history)
+	value := 0.0
+	for a, p := range strategy {
+		value -= p * k.profileValue(cards, history+actionString(a))
+	}
+	return value
+}
@@ -0,0 +1,26 @@
+package kuhn
+
+import "testing"
+
+func TestExploitabilityDecreases(t *testing.T) {
+	trainer := NewKuhnTrainer(WithExploitabilityInterval(10000))
+	if e := trainer.Exploitability(); e < 100 {
+		t.Fatalf("uniform random play is exploitable by %.1f milli-chips, want far more", e)
+	}
+
+	trainer.Train(100000)
+	if len(trainer.Convergence) != 10 {
+		t.Fatalf("recorded %d points, want 10", len(trainer.Convergence))
+	}
+	first := trainer.Convergence[0].Exploitability
+	last := trainer.Convergence[len(trainer.Convergence)-1].Exploitability
+	if last >= first {
+		t.Errorf("exploitability went from %.2f to %.2f, want it to fall", first, last)
+	}
+	if last > 20 {
+		t.Errorf("exploitability after 100000 iterations = %.2f milli-chips, want under 20", last)
+	}
+	if last < 0 {
+		t.Errorf("exploitability = %.2f, must never be negative", last)
+	}
+}
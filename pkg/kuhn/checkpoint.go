@@ -0,0 +1,46 @@
+package kuhn
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/pepperonirollz/cfr/pkg/snapshot"
+)
+
+// WithCheckpoint makes Train write a checkpoint to path every n iterations.
+// A checkpoint is a snapshot that also records the generator state, so
+// Resume can carry on exactly where it was taken.
+func WithCheckpoint(path string, n int) Option {
+	return func(k *KuhnTrainer) {
+		k.checkpointPath = path
+		k.checkpointEvery = n
+	}
+}
+
+// Resume loads the checkpoint set with WithCheckpoint, if one has been
+// written, and trains until iterations have run in total. Killing a run and
+// calling Resume again with the same options and iterations gives the same
+// NodeMap as an uninterrupted run.
+func (k *KuhnTrainer) Resume(iterations int) error {
+	if k.checkpointPath == "" {
+		return errors.New("kuhn: Resume needs WithCheckpoint")
+	}
+	err := k.Load(k.checkpointPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if k.iterations >= iterations {
+		return nil
+	}
+	return k.train(iterations - k.iterations)
+}
+
+// CheckpointErr is the error from the first checkpoint Train failed to
+// write, or nil. Resume returns the error itself.
+func (k *KuhnTrainer) CheckpointErr() error {
+	return k.checkpointErr
+}
+
+func (k *KuhnTrainer) checkpoint() error {
+	return snapshot.Save(k.checkpointPath, k.Snapshot(), snapshot.Binary)
+}
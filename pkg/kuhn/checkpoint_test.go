@@ -0,0 +1,42 @@
+package kuhn
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResumeMatchesUninterruptedRun(t *testing.T) {
+	for _, variant := range []Variant{Vanilla, CFRPlus} {
+		iterations := 20000
+		if variant != Vanilla {
+			iterations = 200
+		}
+		straight := NewKuhnTrainer(WithSeed(7), WithVariant(variant))
+		straight.Train(iterations)
+
+		path := filepath.Join(t.TempDir(), "kuhn.ckpt")
+		interrupted := NewKuhnTrainer(WithSeed(7), WithVariant(variant), WithCheckpoint(path, iterations/10))
+		// stop partway between two checkpoints, as if the job were killed
+		interrupted.Train(iterations/2 + iterations/20)
+
+		resumed := NewKuhnTrainer(WithSeed(7), WithVariant(variant), WithCheckpoint(path, iterations/10))
+		if err := resumed.Resume(iterations); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(resumed.Snapshot(), straight.Snapshot()) {
+			t.Errorf("variant %d: resumed run differs from the uninterrupted one", variant)
+		}
+	}
+}
+
+func TestCheckpointErr(t *testing.T) {
+	k := NewKuhnTrainer(WithSeed(1), WithCheckpoint(filepath.Join(t.TempDir(), "missing", "kuhn.ckpt"), 10))
+	k.Train(20)
+	if k.CheckpointErr() == nil {
+		t.Error("Train wrote a checkpoint into a directory that does not exist")
+	}
+	if k.Resume(40) == nil {
+		t.Error("Resume wrote a checkpoint into a directory that does not exist")
+	}
+}
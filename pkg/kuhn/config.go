@@ -0,0 +1,95 @@
+package kuhn
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Config is the rules a Kuhn game is played by: the ranks in the deck and
+// the stakes. A Game takes its rules from its AI's trainer, so the AI has a
+// strategy for every information set the game can reach.
+type Config struct {
+	// Deck holds one card per rank, in any order.
+	Deck    []rune
+	Ante    int
+	BetSize int
+	// Stack is what each side starts a match with.
+	Stack int
+	// Players is how many are dealt in, each a card from Deck. A Game is
+	// always two players.
+	Players int
+}
+
+// DefaultConfig is the 13 rank deck RoboDurrr has always played.
+func DefaultConfig() Config {
+	return Config{
+		Deck:    []rune{'2', '3', '4', '5', '6', '7', '8', '9', 'T', 'J', 'Q', 'K', 'A'},
+		Ante:    1,
+		BetSize: 1,
+		Stack:   10,
+		Players: 2,
+	}
+}
+
+// ClassicConfig is Kuhn's original J, Q, K game, which is worth -1/18 per
+// hand to the first player.
+func ClassicConfig() Config {
+	c := DefaultConfig()
+	c.Deck = []rune{'J', 'Q', 'K'}
+	return c
+}
+
+// NewKuhnTrainerForConfig is NewKuhnTrainer dealing from c.Deck to
+// c.Players and paying out c's stakes. It fails when c does not pass
+// Validate, or when a game of more than two players is given a variant,
+// workers or vector form, since it only trains with chance-sampled vanilla
+// CFR.
+func NewKuhnTrainerForConfig(c Config, opts ...Option) (KuhnTrainer, error) {
+	if err := c.Validate(); err != nil {
+		return KuhnTrainer{}, err
+	}
+	k := NewKuhnTrainer(opts...)
+	k.config = c
+	if c.Players > 2 && (k.variant != Vanilla || k.workers > 1 || k.vector) {
+		return KuhnTrainer{}, fmt.Errorf("kuhn: %d players train with vanilla CFR on one goroutine only", c.Players)
+	}
+	return k, nil
+}
+
+// Config returns the rules the trainer plays by.
+func (k *KuhnTrainer) Config() Config {
+	return k.config
+}
+
+// Validate reports whether c is a game that can be played.
+func (c Config) Validate() error {
+	if c.Players < 2 {
+		return errors.New("kuhn: a game needs at least 2 players")
+	}
+	if len(c.Deck) < c.Players {
+		return fmt.Errorf("kuhn: %d players need at least %d cards", c.Players, c.Players)
+	}
+	seen := make(map[rune]bool)
+	for _, card := range c.Deck {
+		if GetCardRank(card) == 0 {
+			return fmt.Errorf("kuhn: %q is not a rank", card)
+		}
+		if seen[card] {
+			return fmt.Errorf("kuhn: %c is in the deck twice", card)
+		}
+		seen[card] = true
+	}
+	if c.Ante < 1 || c.BetSize < 1 {
+		return errors.New("kuhn: the ante and bet must be at least 1")
+	}
+	if c.Stack < c.minStack() {
+		return fmt.Errorf("kuhn: a stack of %d cannot cover an ante and a bet", c.Stack)
+	}
+	return nil
+}
+
+// minStack is what a player needs to ante and then bet or call, so no hand
+// is ever dealt that a stack cannot cover.
+func (c Config) minStack() int {
+	return c.Ante + c.BetSize
+}
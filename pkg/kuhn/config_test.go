@@ -0,0 +1,126 @@
+package kuhn
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestClassicValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   float64
+	}{
+		{"classic", ClassicConfig(), -1.0 / 18},
+		// Doubling both stakes doubles every payoff, so the value doubles.
+		{"double stakes", Config{Deck: []rune{'K', 'Q', 'J'}, Ante: 2, BetSize: 2, Stack: 20, Players: 2}, -2.0 / 18},
+	}
+	for _, tc := range tests {
+		trainer := newTrainer(t, tc.config, WithSeed(1), WithVariant(CFRPlus))
+		trainer.Train(2000)
+		if got := trainer.Value(); math.Abs(got-tc.want) > 0.002 {
+			t.Errorf("%s: value %.4f, want %.4f", tc.name, got, tc.want)
+		}
+		if n := len(trainer.NodeMap); n != 12 {
+			t.Errorf("%s: %d information sets, want 12", tc.name, n)
+		}
+	}
+}
+
+func TestGameStakes(t *testing.T) {
+	ai := newTrainer(t, Config{Deck: []rune{'J', 'Q', 'K'}, Ante: 2, BetSize: 3, Stack: 30, Players: 2}, WithSeed(1))
+	ai.Train(1000)
+	g := newGame(t, ai, WithGameSeed(2))
+	g.aiPolicy = func(string) Action { return Bet }
+	g.BeginRound()
+
+	if g.StartingStack != 30 || g.Pot != 4 || g.PlayerStack+g.AiStack != 56 {
+		t.Fatalf("stack %d, pot %d after the antes", g.StartingStack, g.Pot)
+	}
+	for g.HandNumber <= 5 {
+		if !strings.ContainsRune("JQK", g.PlayerCard) {
+			t.Fatalf("dealt %c from a J, Q, K deck", g.PlayerCard)
+		}
+		if err := g.Bet(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, h := range g.History {
+		if h.Ante != 2 || h.Pot != 10 || h.PlayerDelta != -h.AiDelta || abs(h.PlayerDelta) != 5 {
+			t.Errorf("hand %d: ante %d, pot %d, deltas %d and %d", h.Number, h.Ante, h.Pot, h.PlayerDelta, h.AiDelta)
+		}
+	}
+	if !strings.Contains(g.Log(), "Player 1 antes 2\n") {
+		t.Errorf("log does not show the ante:\n%s", g.Log())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		ok     bool
+	}{
+		{"default", DefaultConfig(), true},
+		{"classic", ClassicConfig(), true},
+		{"two cards", Config{Deck: []rune{'2', 'A'}, Ante: 1, BetSize: 1, Stack: 2, Players: 2}, true},
+		{"one card", Config{Deck: []rune{'A'}, Ante: 1, BetSize: 1, Stack: 2, Players: 2}, false},
+		{"not a rank", Config{Deck: []rune{'J', 'X'}, Ante: 1, BetSize: 1, Stack: 2, Players: 2}, false},
+		{"same rank twice", Config{Deck: []rune{'J', 'J', 'Q'}, Ante: 1, BetSize: 1, Stack: 2, Players: 2}, false},
+		{"no ante", Config{Deck: []rune{'J', 'Q'}, BetSize: 1, Stack: 2, Players: 2}, false},
+		{"short stack", Config{Deck: []rune{'J', 'Q'}, Ante: 2, BetSize: 2, Stack: 3, Players: 2}, false},
+		{"three players", ThreePlayerConfig(), true},
+		{"no players", Config{Deck: []rune{'J', 'Q'}, Ante: 1, BetSize: 1, Stack: 2}, false},
+		{"more players than cards", Config{Deck: []rune{'J', 'Q', 'K', 'A'}, Ante: 1, BetSize: 1, Stack: 2, Players: 5}, false},
+	}
+	for _, tc := range tests {
+		if err := tc.config.Validate(); (err == nil) != tc.ok {
+			t.Errorf("%s: Validate() = %v", tc.name, err)
+		}
+	}
+}
+
+// TestInvalidConfigIsReported checks that no way of building a trainer or a
+// game accepts a deck whose cards would share a rank of 0.
+func TestInvalidConfigIsReported(t *testing.T) {
+	bad := Config{Deck: []rune{'J', 'X', 'Y'}, Ante: 1, BetSize: 1, Stack: 10, Players: 2}
+	if _, err := NewKuhnTrainerForConfig(bad); err == nil {
+		t.Error("NewKuhnTrainerForConfig accepted a deck with two unknown ranks")
+	}
+	if _, err := NewKuhnTrainerForConfig(ClassicConfig()); err != nil {
+		t.Errorf("NewKuhnTrainerForConfig rejected the classic deck: %v", err)
+	}
+
+	for _, opt := range []Option{WithVariant(CFRPlus), WithDCFR(), WithWorkers(2), WithVectorForm()} {
+		if _, err := NewKuhnTrainerForConfig(ThreePlayerConfig(), opt); err == nil {
+			t.Error("NewKuhnTrainerForConfig accepted a three-player game it can only train with vanilla CFR")
+		}
+	}
+
+	var unconfigured KuhnTrainer
+	if _, err := NewGameWithAi(unconfigured); err == nil {
+		t.Error("NewGameWithAi accepted an AI with an empty deck")
+	}
+	if _, err := NewGameWithAi(newTrainer(t, ThreePlayerConfig())); err == nil {
+		t.Error("NewGameWithAi accepted a three-player AI")
+	}
+}
+
+// newTrainer is NewKuhnTrainerForConfig for a config the test expects to be
+// valid.
+func newTrainer(t testing.TB, c Config, opts ...Option) KuhnTrainer {
+	t.Helper()
+	k, err := NewKuhnTrainerForConfig(c, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,75 @@
+package kuhn
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotYourTurn  = errors.New("it is not the player's turn")
+	ErrGameOver     = errors.New("the hand or match is over")
+	ErrNoSuchAction = errors.New("no such action")
+)
+
+// ActionError is returned for an action the player cannot take. Err is one
+// of ErrNotYourTurn, ErrGameOver or ErrNoSuchAction.
+type ActionError struct {
+	Action string
+	State  GameState
+	Err    error
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("kuhn: cannot %s in state %s: %v", e.Action, e.State, e.Err)
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
+
+// ParseAction reads an action by name. Check and pass are the same action,
+// which folds when facing a bet, as are bet and call.
+func ParseAction(name string) (Action, error) {
+	switch name {
+	case "check", "pass", "fold":
+		return Pass, nil
+	case "bet", "call":
+		return Bet, nil
+	}
+	return 0, &ActionError{Action: name, Err: ErrNoSuchAction}
+}
+
+// Act takes action for the player.
+func (g *Game) Act(action Action) error {
+	switch action {
+	case Pass:
+		return g.Check()
+	case Bet:
+		return g.Bet()
+	}
+	return &ActionError{Action: fmt.Sprintf("action %d", action), State: g.GameState, Err: ErrNoSuchAction}
+}
+
+// ExpectTurn returns ErrNotYourTurn unless the game is at history in hand, so
+// a client that acted on a stale view, such as a double-submitted form,
+// cannot act twice.
+func (g *Game) ExpectTurn(hand int, history string) error {
+	if g.HandNumber != hand || g.ActionHistory != history {
+		return &ActionError{Action: "act", State: g.GameState, Err: ErrNotYourTurn}
+	}
+	return nil
+}
+
+// playerTurn returns an error unless the player is to act in a hand in
+// progress. Whoever is to act follows from how many actions the hand has had.
+func (g *Game) playerTurn(action string) error {
+	switch g.GameState {
+	case FirstAction, SecondAction, ThirdAction:
+	default:
+		return &ActionError{Action: action, State: g.GameState, Err: ErrGameOver}
+	}
+	if g.CurrentHand.Number == 0 || len(g.ActionHistory)%2 != int(g.PlayerPosition) {
+		return &ActionError{Action: action, State: g.GameState, Err: ErrNotYourTurn}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package kuhn
+
+import (
+	"errors"
+	"testing"
+)
+
+// steeredGame deals a hand in which the AI takes aiActions in order, and
+// passes once they run out.
+func steeredGame(t *testing.T, playerFirst bool, aiActions ...Action) *Game {
+	t.Helper()
+	g := newMatch(t)
+	g.History, g.HandNumber = nil, 1
+	g.PlayerStack, g.AiStack = 10, 10
+	if !playerFirst {
+		g.PlayerPosition, g.AiPosition = second, first
+	}
+	g.aiPolicy = func(string) Action {
+		if len(aiActions) == 0 {
+			return Pass
+		}
+		action := aiActions[0]
+		aiActions = aiActions[1:]
+		return action
+	}
+	g.BeginRound()
+	return g
+}
+
+func TestStateTransitions(t *testing.T) {
+	tests := []struct {
+		name        string
+		playerFirst bool
+		ai          []Action
+		player      []Action
+		// states after each of the player's actions but the last, which ends
+		// the hand
+		states   []GameState
+		history  string
+		winner   string // "" for the higher card
+		showdown bool
+	}{
+		{"check, check", true, []Action{Pass}, []Action{Pass}, nil, "pp", "", true},
+		{"check, bet, fold", true, []Action{Bet}, []Action{Pass, Pass}, []GameState{ThirdAction}, "pbp", AiActor, false},
+		{"check, bet, call", true, []Action{Bet}, []Action{Pass, Bet}, []GameState{ThirdAction}, "pbb", "", true},
+		{"bet, fold", true, []Action{Pass}, []Action{Bet}, nil, "bp", PlayerActor, false},
+		{"bet, call", true, []Action{Bet}, []Action{Bet}, nil, "bb", "", true},
+		{"AI checks, check", false, []Action{Pass}, []Action{Pass}, nil, "pp", "", true},
+		{"AI checks, bet, fold", false, []Action{Pass, Pass}, []Action{Bet}, nil, "pbp", PlayerActor, false},
+		{"AI checks, bet, call", false, []Action{Pass, Bet}, []Action{Bet}, nil, "pbb", "", true},
+		{"AI bets, fold", false, []Action{Bet}, []Action{Pass}, nil, "bp", AiActor, false},
+		{"AI bets, call", false, []Action{Bet}, []Action{Bet}, nil, "bb", "", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := steeredGame(t, tc.playerFirst, tc.ai...)
+			winner := tc.winner
+			if winner == "" && GetCardRank(g.PlayerCard) > GetCardRank(g.AiCard) {
+				winner = PlayerActor
+			} else if winner == "" {
+				winner = AiActor
+			}
+			for i, action := range tc.player {
+				if err := g.Act(action); err != nil {
+					t.Fatalf("action %d: %v", i, err)
+				}
+				if i < len(tc.states) && g.GameState != tc.states[i] {
+					t.Errorf("after action %d: state %v, want %v", i, g.GameState, tc.states[i])
+				}
+			}
+			if len(g.History) != 1 {
+				t.Fatalf("%d hands finished, want 1", len(g.History))
+			}
+			h := g.History[0]
+			var history string
+			for _, a := range h.Actions {
+				if a.Action == "bet" || a.Action == "call" {
+					history += "b"
+				} else {
+					history += "p"
+				}
+			}
+			if history != tc.history || h.Winner != winner || h.Showdown != tc.showdown {
+				t.Errorf("hand went %s, won by %s, showdown %v; want %s, %s, %v",
+					history, h.Winner, h.Showdown, tc.history, winner, tc.showdown)
+			}
+			if err := g.playerTurn("check"); err != nil {
+				t.Errorf("the next hand is not waiting for the player: %v", err)
+			}
+		})
+	}
+}
+
+func TestIllegalActions(t *testing.T) {
+	unstarted := newGame(t, NewKuhnTrainer())
+	if err := unstarted.Check(); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("check before the first deal: %v", err)
+	}
+
+	for _, state := range []GameState{Showdown, PlayerFolded, AiFolded, MatchOver} {
+		g := steeredGame(t, true)
+		g.GameState = state
+		for _, action := range []Action{Pass, Bet} {
+			if err := g.Act(action); !errors.Is(err, ErrGameOver) {
+				t.Errorf("%v in %v: %v", action, state, err)
+			}
+		}
+	}
+
+	// The player opens, so after one action it is the AI's turn.
+	g := steeredGame(t, true)
+	g.ActionHistory = "p"
+	g.GameState = SecondAction
+	if err := g.Bet(); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("bet on the AI's turn: %v", err)
+	}
+	var actionErr *ActionError
+	if err := g.Check(); !errors.As(err, &actionErr) || actionErr.State != SecondAction || actionErr.Action != "check" {
+		t.Errorf("check on the AI's turn: %#v", err)
+	}
+
+	g = steeredGame(t, true)
+	if err := g.Act(Action(7)); !errors.Is(err, ErrNoSuchAction) {
+		t.Errorf("action 7: %v", err)
+	}
+	if _, err := ParseAction("raise"); !errors.Is(err, ErrNoSuchAction) {
+		t.Errorf("parsing raise: %v", err)
+	}
+	if len(g.History) != 0 || g.ActionHistory != "" || g.PlayerStack != 9 {
+		t.Error("a rejected action changed the game")
+	}
+}
+
+func TestExpectTurnStopsDoubleSubmit(t *testing.T) {
+	g := steeredGame(t, true, Bet)
+	hand, history := g.HandNumber, g.ActionHistory
+	if err := g.ExpectTurn(hand, history); err != nil {
+		t.Fatal(err)
+	}
+	g.Check()
+	if err := g.ExpectTurn(hand, history); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("the same request again: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package kuhn
+
+type EventType string
+
+const (
+	// DealEvent starts a hand. Only the player's card is revealed.
+	DealEvent EventType = "deal"
+	// ActionEvent is a check, bet, call or fold by Actor.
+	ActionEvent EventType = "action"
+	// ShowdownEvent reveals both cards and the winner.
+	ShowdownEvent EventType = "showdown"
+	// StackEvent follows every change to the stacks: antes, bets and the pot
+	// going to Winner.
+	StackEvent EventType = "stack"
+	// MatchEndEvent ends the match, with Winner ahead overall.
+	MatchEndEvent EventType = "matchEnd"
+)
+
+const (
+	PlayerActor = "player"
+	AiActor     = "ai"
+)
+
+type Event struct {
+	Type           EventType `json:"type"`
+	Hand           int       `json:"hand"`
+	PlayerPosition int       `json:"playerPosition"`
+	Actor          string    `json:"actor,omitempty"`
+	Action         string    `json:"action,omitempty"`
+	// Amount is the chips bet or called, put in as antes, or won.
+	Amount      int    `json:"amount,omitempty"`
+	PlayerCard  string `json:"playerCard,omitempty"`
+	AiCard      string `json:"aiCard,omitempty"`
+	Winner      string `json:"winner,omitempty"`
+	PlayerStack int    `json:"playerStack"`
+	AiStack     int    `json:"aiStack"`
+	Pot         int    `json:"pot"`
+}
+
+// WithEvents makes the game call f with every event as it happens, while the
+// caller of Check, Bet or BeginRound is still inside it.
+func WithEvents(f func(Event)) GameOption {
+	return func(g *Game) {
+		g.onEvent = f
+	}
+}
+
+// emit fills in the hand and stacks and passes e to the listener.
+func (g *Game) emit(e Event) {
+	if g.onEvent == nil {
+		return
+	}
+	e.Hand = g.HandNumber
+	e.PlayerPosition = int(g.PlayerPosition)
+	e.PlayerStack = g.PlayerStack
+	e.AiStack = g.AiStack
+	e.Pot = g.Pot
+	g.onEvent(e)
+}
+
+// act records actor's action in CurrentHand and emits it, followed by the
+// stack change when it put chips in.
+func (g *Game) act(actor, action string, amount int) {
+	g.CurrentHand.Actions = append(g.CurrentHand.Actions, ActionRecord{Actor: actor, Action: action, Amount: amount})
+	g.CurrentHand.Pot = g.Pot
+	g.emit(Event{Type: ActionEvent, Actor: actor, Action: action, Amount: amount})
+	if amount > 0 {
+		g.emit(Event{Type: StackEvent, Actor: actor, Amount: amount})
+	}
+}
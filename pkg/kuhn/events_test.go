@@ -0,0 +1,56 @@
+package kuhn
+
+import "testing"
+
+func TestGameEvents(t *testing.T) {
+	ai := NewKuhnTrainer(WithSeed(1))
+	ai.Train(10000)
+	var events []Event
+	g := newGame(t, ai, WithGameSeed(2), WithEvents(func(e Event) {
+		events = append(events, e)
+	}))
+	g.BeginRound()
+	for i := 0; i < 60; i++ {
+		if i%3 == 0 {
+			g.Bet()
+		} else {
+			g.Check()
+		}
+	}
+
+	deals, showdowns, pots := 0, 0, 0
+	for i, e := range events {
+		if total := e.PlayerStack + e.AiStack + e.Pot; total != 20 {
+			t.Fatalf("event %d %+v: chips add up to %d", i, e, total)
+		}
+		switch e.Type {
+		case DealEvent:
+			deals++
+			if e.AiCard != "" {
+				t.Errorf("deal %d reveals the AI's card", e.Hand)
+			}
+		case ShowdownEvent:
+			showdowns++
+			if e.PlayerCard == "" || e.AiCard == "" || e.Winner == "" {
+				t.Errorf("incomplete showdown %+v", e)
+			}
+		case StackEvent:
+			if e.Winner != "" {
+				pots++
+				if e.Pot != 0 {
+					t.Errorf("pot still holds %d after it was won", e.Pot)
+				}
+			}
+		case ActionEvent:
+			if e.Actor != PlayerActor && e.Actor != AiActor {
+				t.Errorf("action by %q", e.Actor)
+			}
+		}
+	}
+	if deals != g.HandNumber || pots != g.HandNumber-1 {
+		t.Errorf("%d deals and %d pots won over %d hands", deals, pots, g.HandNumber)
+	}
+	if showdowns == 0 || showdowns > pots {
+		t.Errorf("%d showdowns for %d pots", showdowns, pots)
+	}
+}
@@ -2,8 +2,10 @@ package kuhn
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
 	"sync"
+	"time"
 )
 
 type Position int
@@ -29,20 +31,30 @@ const (
 	PlayerFolded
 	AiFolded
 	Showdown
+	// MatchOver means no more hands are dealt until the player rebuys.
+	MatchOver
 )
 
-type GameLogger struct {
-	Log string
-}
-
-func newGameLogger(s string) *GameLogger {
-	return &GameLogger{
-		Log: s + "\n",
+func (s GameState) String() string {
+	switch s {
+	case FirstAction:
+		return "firstAction"
+	case SecondAction:
+		return "secondAction"
+	case ThirdAction:
+		return "thirdAction"
+	case PlayerFolded:
+		return "playerFolded"
+	case AiFolded:
+		return "aiFolded"
+	case Showdown:
+		return "showdown"
+	case MatchOver:
+		return "matchOver"
+	default:
+		return "unknown"
 	}
 }
-func (l *GameLogger) append(s string) {
-	l.Log += s + "\n"
-}
 
 type Game struct {
 	sync.Mutex
@@ -55,55 +67,156 @@ type Game struct {
 	Ai               KuhnTrainer
 	GameState        GameState
 	Pot              int
-	GameLog          *GameLogger
 	ActionHistory    string
 	HandNumber       int
 	PlayerPosition   Position
 	AiPosition       Position
 	PlayerLastAction Action
 	AiLastAction     Action
+	CurrentHand      HandRecord
+	// History holds every finished hand, oldest first.
+	History []HandRecord
+	// Ante and BetSize are the stakes of the AI's Config.
+	Ante    int
+	BetSize int
+	// StartingStack is what each side starts the match with and what a
+	// rebuy adds, and MaxHands ends the match after that many hands when it
+	// is positive.
+	StartingStack int
+	MaxHands      int
+	Rebuys        int
+	MatchEnd      MatchEnd
+	// OnlineLearning makes the AI update its strategy from every hand it
+	// finishes, using outcome sampling. Set it with WithOnlineLearning, which
+	// gives the game its own copy of the AI's nodes to learn in.
+	OnlineLearning bool
+	// rng deals the cards and samples the AI's actions.
+	rng *rand.Rand
+	// onEvent is called with every event as it happens.
+	onEvent func(Event)
+	// handWriter receives each finished hand as a line of JSON.
+	handWriter    io.Writer
+	handWriterErr error
+	onHand        func(HandRecord)
+	// aiPolicy replaces sampling the AI's action from its strategy, so tests
+	// can steer a hand.
+	aiPolicy func(infoSet string) Action
+}
+
+type GameOption func(*Game)
+
+// WithGameSeed makes the deals and the AI's choices reproducible.
+func WithGameSeed(seed int64) GameOption {
+	return WithGameRand(rand.New(rand.NewSource(seed)))
+}
+
+// WithGameRand makes the game deal and sample the AI's actions with r. The
+// game only draws from it while locked, so nothing else should draw from r.
+func WithGameRand(r *rand.Rand) GameOption {
+	return func(g *Game) {
+		g.rng = r
+	}
 }
 
-func NewGame() *Game {
-	d := []rune{'2', '3', '4', '5', '6', '7', '8', '9', 'T', 'J', 'Q', 'K', 'A'}
+// WithOnlineLearning makes the AI learn from every hand of this game. The
+// game learns in a copy of the AI's nodes, so the trainer passed to
+// NewGameWithAi, and every other game playing it, is left alone.
+func WithOnlineLearning() GameOption {
+	return func(g *Game) {
+		g.OnlineLearning = true
+	}
+}
+
+func NewGame(opts ...GameOption) (*Game, error) {
 	trainer := NewKuhnTrainer()
 	trainer.Train(100000)
-	Shuffle(d)
-	return &Game{
-		PlayerStack:    10,
-		AiStack:        10,
-		Deck:           d,
-		Ai:             trainer,
+	return NewGameWithAi(trainer, opts...)
+}
+
+// NewGameWithAi starts a game against an already trained or loaded AI, played
+// by the AI's Config. It fails if the Config is not valid or not for two
+// players.
+func NewGameWithAi(ai KuhnTrainer, opts ...GameOption) (*Game, error) {
+	config := ai.Config()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Players != 2 {
+		return nil, fmt.Errorf("kuhn: a game needs a two-player AI, not %d players", config.Players)
+	}
+	g := &Game{
+		Ante:           config.Ante,
+		BetSize:        config.BetSize,
+		StartingStack:  config.Stack,
+		Deck:           append([]rune(nil), config.Deck...),
+		Ai:             ai,
 		Pot:            0,
-		GameLog:        newGameLogger("Starting game\n"),
 		GameState:      FirstAction,
 		HandNumber:     1,
 		PlayerPosition: first,
 		AiPosition:     second,
+		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(g)
 	}
+	if g.OnlineLearning {
+		g.Ai = ai.clone()
+	}
+	g.PlayerStack = g.StartingStack
+	g.AiStack = g.StartingStack
+	Shuffle(g.Deck, g.rng)
+	return g, nil
 }
 
-func (g *Game) BeginRound() {
+// BeginRound deals the next hand, and plays the AI's first action when it
+// is first to act.
+func (g *Game) BeginRound() error {
+	if g.GameState == MatchOver {
+		return nil
+	}
+	if end, over := g.matchOver(); over {
+		g.endMatch(end)
+		return nil
+	}
 	g.GameState = FirstAction
-	Shuffle(g.Deck)
+	Shuffle(g.Deck, g.rng)
 	g.PlayerCard = g.Deck[0]
 	g.AiCard = g.Deck[1]
-	g.PlayerStack--
-	g.AiStack--
-	g.Pot = 2
+	g.PlayerStack -= g.Ante
+	g.AiStack -= g.Ante
+	g.Pot = 2 * g.Ante
 	g.ActionHistory = ""
-	g.GameLog.append(fmt.Sprintf("Player 1 antes 1\nRoboDurrr antes 1\nYou've been dealt a %c\n", g.PlayerCard))
-	if g.PlayerPosition == 0 {
-		g.GameLog.append("...waiting for action...")
+	g.CurrentHand = HandRecord{
+		Number:         g.HandNumber,
+		PlayerPosition: int(g.PlayerPosition),
+		AiPosition:     int(g.AiPosition),
+		PlayerCard:     string(g.PlayerCard),
+		AiCard:         string(g.AiCard),
+		Ante:           g.Ante,
+		Pot:            g.Pot,
 	}
+	g.emit(Event{Type: DealEvent, PlayerCard: string(g.PlayerCard), PlayerPosition: int(g.PlayerPosition)})
+	g.emit(Event{Type: StackEvent, Amount: g.Pot})
 	if g.AiPosition == 0 {
-		g.AiResponse()
+		return g.AiResponse()
 	}
+	return nil
 }
-func (g *Game) getAiAction() Action {
+
+// getAiAction samples the AI's action from its average strategy. It fails
+// if the AI has no node for the information set, which happens when its
+// nodes were trained for another deck.
+func (g *Game) getAiAction() (Action, error) {
 	infoset := fmt.Sprintf("%d %c%s", g.AiPosition, g.AiCard, g.ActionHistory)
-	node := g.Ai.NodeMap[infoset]
-	randomNumber := rand.Float64()
+	if g.aiPolicy != nil {
+		return g.aiPolicy(infoset), nil
+	}
+	node, ok := g.Ai.NodeMap[infoset]
+	if !ok {
+		return Pass, fmt.Errorf("kuhn: the AI has no strategy for %q", infoset)
+	}
+	randomNumber := g.rng.Float64()
 	fmt.Println("Ai strategy: ", node.GetAvgStrategy())
 	cumulativeProbability := 0.0
 	var action int
@@ -115,151 +228,199 @@ func (g *Game) getAiAction() Action {
 		}
 	}
 	if action == 0 {
-		return Pass
+		return Pass, nil
 	} else {
-		return Bet
+		return Bet, nil
 	}
 }
 
-func (g *Game) AiResponse() {
-	action := g.getAiAction()
+func (g *Game) AiResponse() error {
+	action, err := g.getAiAction()
+	if err != nil {
+		return err
+	}
 
 	switch g.GameState {
 	case FirstAction:
 		if action == Bet {
 			g.ActionHistory = g.ActionHistory + "b"
-			g.GameLog.append("RoboDurrr has bet 1 currency\n...Waiting for your action...")
-			g.Pot++
-			g.AiStack--
+			g.Pot += g.BetSize
+			g.AiStack -= g.BetSize
 			g.AiLastAction = Bet
 			g.GameState = SecondAction
+			g.act(AiActor, "bet", g.BetSize)
 		} else {
 			g.ActionHistory = g.ActionHistory + "p"
-			g.GameLog.append("RoboDurrr checked")
 			g.AiLastAction = Pass
 			g.GameState = SecondAction
+			g.act(AiActor, "check", 0)
 		}
 	case SecondAction:
 		if action == Bet && g.PlayerLastAction == Bet {
-			g.Pot++
-			g.AiStack--
-			g.GameLog.append("RoboDurrr has called")
+			g.ActionHistory = g.ActionHistory + "b"
+			g.Pot += g.BetSize
+			g.AiStack -= g.BetSize
 			g.GameState = Showdown
-			resolveRound(g)
+			g.act(AiActor, "call", g.BetSize)
+			return resolveRound(g)
 		} else if action == Bet && g.PlayerLastAction == Pass {
-			g.GameLog.append("RoboDurrr has bet 1 currency\n...Waiting for your action...")
-			g.Pot++
-			g.AiStack--
+			g.ActionHistory = g.ActionHistory + "b"
+			g.Pot += g.BetSize
+			g.AiStack -= g.BetSize
 			g.AiLastAction = Bet
 			g.GameState = ThirdAction
+			g.act(AiActor, "bet", g.BetSize)
 		} else if action == Pass && g.PlayerLastAction == Pass {
-			g.GameLog.append("RoboDurrr checked behind")
+			g.ActionHistory = g.ActionHistory + "p"
 			g.GameState = Showdown
-			resolveRound(g)
+			g.act(AiActor, "check", 0)
+			return resolveRound(g)
 		} else if action == Pass && g.PlayerLastAction == Bet {
-			g.GameLog.append("RoboDurrr has folded")
+			g.ActionHistory = g.ActionHistory + "p"
 			g.GameState = AiFolded
-			resolveRound(g)
+			g.act(AiActor, "fold", 0)
+			return resolveRound(g)
 		}
 	case ThirdAction:
 		if action == Bet {
-			g.Pot++
-			g.AiStack--
-			g.GameLog.append("RoboDurrr has called")
+			g.ActionHistory = g.ActionHistory + "b"
+			g.Pot += g.BetSize
+			g.AiStack -= g.BetSize
 			g.GameState = Showdown
-			resolveRound(g)
+			g.act(AiActor, "call", g.BetSize)
+			return resolveRound(g)
 		} else {
-			g.GameLog.append("RoboDurrr has folded")
+			g.ActionHistory = g.ActionHistory + "p"
 			g.GameState = AiFolded
-			resolveRound(g)
+			g.act(AiActor, "fold", 0)
+			return resolveRound(g)
 		}
 	}
+	return nil
+}
+
+// Check checks, or folds when facing a bet.
+func (g *Game) Check() error {
+	if err := g.playerTurn("check"); err != nil {
+		return err
+	}
+	return g.check()
 }
 
-func (g *Game) Check() {
+// Bet bets, or calls when facing a bet.
+func (g *Game) Bet() error {
+	if err := g.playerTurn("bet"); err != nil {
+		return err
+	}
+	return g.bet()
+}
+
+func (g *Game) check() error {
 	switch g.GameState {
 	case FirstAction:
 		g.ActionHistory = g.ActionHistory + "p"
-		g.GameLog.append("You have checked")
 		g.GameState = SecondAction
 		g.PlayerLastAction = Pass
-		g.AiResponse()
+		g.act(PlayerActor, "check", 0)
+		return g.AiResponse()
 	case SecondAction: //depends on ai action
+		g.ActionHistory = g.ActionHistory + "p"
 		if g.AiLastAction == Pass {
-			g.GameLog.append("You have checked behind")
 			g.GameState = Showdown
-			resolveRound(g)
+			g.act(PlayerActor, "check", 0)
+			return resolveRound(g)
 		} else {
-			g.GameLog.append("You have folded")
 			g.GameState = PlayerFolded
-			resolveRound(g)
+			g.act(PlayerActor, "fold", 0)
+			return resolveRound(g)
 		}
 	case ThirdAction: //only get third action if you checked and ai bet
-		g.GameLog.append("You have folded")
+		g.ActionHistory = g.ActionHistory + "p"
 		g.GameState = PlayerFolded
-		resolveRound(g)
+		g.act(PlayerActor, "fold", 0)
+		return resolveRound(g)
 	}
+	return nil
 }
-func (g *Game) Bet() {
+
+func (g *Game) bet() error {
 	switch g.GameState {
 	case FirstAction:
 		g.ActionHistory = g.ActionHistory + "b"
-		g.GameLog.append("You have bet 1 currency")
-		g.PlayerStack--
-		g.Pot++
+		g.PlayerStack -= g.BetSize
+		g.Pot += g.BetSize
 		//handle ai response
 		g.GameState = SecondAction
 		g.PlayerLastAction = Bet
-		g.AiResponse()
+		g.act(PlayerActor, "bet", g.BetSize)
+		return g.AiResponse()
 	case SecondAction:
 		if g.AiLastAction == Bet {
-			g.GameLog.append("You have called for 1 currency")
-			g.PlayerStack--
-			g.Pot++
+			g.ActionHistory = g.ActionHistory + "b"
+			g.PlayerStack -= g.BetSize
+			g.Pot += g.BetSize
 			g.GameState = Showdown
-			resolveRound(g)
+			g.act(PlayerActor, "call", g.BetSize)
+			return resolveRound(g)
 		} else { //ai passed
 			g.ActionHistory = g.ActionHistory + "b"
-			g.GameLog.append("You have bet 1 currency")
-			g.PlayerStack--
-			g.Pot++
+			g.PlayerStack -= g.BetSize
+			g.Pot += g.BetSize
 			g.GameState = ThirdAction
-			g.AiResponse()
+			g.act(PlayerActor, "bet", g.BetSize)
+			return g.AiResponse()
 		}
 	case ThirdAction:
-		g.GameLog.append("You have called for 1 currency")
-		g.PlayerStack--
-		g.Pot++
+		g.ActionHistory = g.ActionHistory + "b"
+		g.PlayerStack -= g.BetSize
+		g.Pot += g.BetSize
 		g.GameState = Showdown
-		resolveRound(g)
+		g.act(PlayerActor, "call", g.BetSize)
+		return resolveRound(g)
 	}
+	return nil
 }
 
-func resolveRound(game *Game) {
+func resolveRound(game *Game) error {
 	state := game.GameState
 	fmt.Println("Game is now resolving...")
+	var winner string
 	switch state {
 	case Showdown:
-		game.GameLog.append(fmt.Sprintf("You showdown a %c\nRoboDurrr shows down a %c", game.PlayerCard, game.AiCard))
 		if GetCardRank(game.PlayerCard) > GetCardRank(game.AiCard) {
+			winner = PlayerActor
+		} else {
+			winner = AiActor
+		}
+		game.emit(Event{Type: ShowdownEvent, PlayerCard: string(game.PlayerCard), AiCard: string(game.AiCard), Winner: winner})
+		if winner == PlayerActor {
 			game.PlayerStack += game.Pot
-			game.GameLog.append("You have won!")
 		} else {
 			game.AiStack += game.Pot
-			game.GameLog.append("RoboDurrr has won!")
 		}
 	case PlayerFolded:
+		winner = AiActor
 		game.AiStack += game.Pot
-		game.GameLog.append("RoboDurrr has won!")
 	case AiFolded:
+		winner = PlayerActor
 		game.PlayerStack += game.Pot
-		game.GameLog.append("You have won!")
 	}
-	game.GameLog.append("\n******* New Hand *******\n")
+	won := game.Pot
 	game.Pot = 0
+	game.emit(Event{Type: StackEvent, Winner: winner, Amount: won})
+	game.finishHand(winner, state == Showdown)
+	if game.OnlineLearning {
+		cards := make([]rune, 2)
+		cards[game.PlayerPosition] = game.PlayerCard
+		cards[game.AiPosition] = game.AiCard
+		game.Ai.ObserveHand(cards, game.ActionHistory, int(game.AiPosition))
+	}
 	game.PlayerPosition = (game.PlayerPosition + 1) % 2
 	game.AiPosition = (game.AiPosition + 1) % 2
+	if end, over := game.matchOver(); over {
+		game.endMatch(end)
+		return nil
+	}
 	game.HandNumber++
-	game.BeginRound()
-
+	return game.BeginRound()
 }
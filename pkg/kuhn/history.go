@@ -0,0 +1,183 @@
+package kuhn
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// HandRecord is one hand as it was played. Both cards are kept, so a
+// finished hand can be analyzed even when it ended in a fold.
+type HandRecord struct {
+	Number         int    `json:"hand"`
+	PlayerPosition int    `json:"playerPosition"`
+	AiPosition     int    `json:"aiPosition"`
+	PlayerCard     string `json:"playerCard"`
+	AiCard         string `json:"aiCard"`
+	// Ante is what each player put in before the cards were dealt.
+	Ante    int            `json:"ante"`
+	Actions []ActionRecord `json:"actions"`
+	// Pot is the chips in the middle, antes included, before it was won.
+	Pot      int    `json:"pot"`
+	Winner   string `json:"winner,omitempty"`
+	Showdown bool   `json:"showdown"`
+	// PlayerDelta and AiDelta are how much each stack changed over the hand.
+	PlayerDelta int `json:"playerDelta"`
+	AiDelta     int `json:"aiDelta"`
+}
+
+type ActionRecord struct {
+	Actor  string `json:"actor"`
+	Action string `json:"action"`
+	Amount int    `json:"amount,omitempty"`
+}
+
+// WithHandHistory makes the game write every hand it finishes to w as a line
+// of JSON.
+func WithHandHistory(w io.Writer) GameOption {
+	return func(g *Game) {
+		g.handWriter = w
+	}
+}
+
+// WithHandListener makes the game call f with every hand it finishes.
+func WithHandListener(f func(HandRecord)) GameOption {
+	return func(g *Game) {
+		g.onHand = f
+	}
+}
+
+// WriteJSONL writes hands to w, one JSON object per line.
+func WriteJSONL(w io.Writer, hands []HandRecord) error {
+	enc := json.NewEncoder(w)
+	for _, h := range hands {
+		if err := enc.Encode(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadJSONL reads hands written by WriteJSONL or WithHandHistory.
+func ReadJSONL(r io.Reader) ([]HandRecord, error) {
+	var hands []HandRecord
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var h HandRecord
+		if err := dec.Decode(&h); err != nil {
+			return hands, err
+		}
+		hands = append(hands, h)
+	}
+	return hands, nil
+}
+
+// Log is the game so far in words, rendered from History and CurrentHand.
+func (g *Game) Log() string {
+	var b strings.Builder
+	b.WriteString("Starting game\n\n")
+	for _, h := range g.History {
+		h.writeText(&b)
+	}
+	if g.GameState == MatchOver {
+		b.WriteString(g.Summary().String())
+		b.WriteString("\n")
+	} else {
+		g.CurrentHand.writeText(&b)
+	}
+	return b.String()
+}
+
+// finishHand settles the current hand's result and moves it to History.
+func (g *Game) finishHand(winner string, showdown bool) {
+	h := g.CurrentHand
+	h.Winner = winner
+	h.Showdown = showdown
+	h.PlayerDelta, h.AiDelta = -h.Ante, -h.Ante
+	for _, a := range h.Actions {
+		if a.Actor == PlayerActor {
+			h.PlayerDelta -= a.Amount
+		} else {
+			h.AiDelta -= a.Amount
+		}
+	}
+	if winner == PlayerActor {
+		h.PlayerDelta += h.Pot
+	} else {
+		h.AiDelta += h.Pot
+	}
+	g.History = append(g.History, h)
+	if g.onHand != nil {
+		g.onHand(h)
+	}
+	if g.handWriter != nil {
+		if err := WriteJSONL(g.handWriter, []HandRecord{h}); err != nil && g.handWriterErr == nil {
+			g.handWriterErr = err
+		}
+	}
+}
+
+// HandHistoryErr returns the first error writing a finished hand to the
+// WithHandHistory writer since it was last called, and forgets it. Play
+// goes on when a hand cannot be written.
+func (g *Game) HandHistoryErr() error {
+	err := g.handWriterErr
+	g.handWriterErr = nil
+	return err
+}
+
+func (h HandRecord) writeText(b *strings.Builder) {
+	fmt.Fprintf(b, "Player 1 antes %d\nRoboDurrr antes %d\nYou've been dealt a %s\n\n", h.Ante, h.Ante, h.PlayerCard)
+	if h.PlayerPosition == 0 {
+		b.WriteString("...waiting for action...\n")
+	}
+	for i, a := range h.Actions {
+		b.WriteString(a.text(i > 0))
+		b.WriteString("\n")
+	}
+	if h.Winner == "" {
+		return
+	}
+	if h.Showdown {
+		fmt.Fprintf(b, "You showdown a %s\nRoboDurrr shows down a %s\n", h.PlayerCard, h.AiCard)
+	}
+	if h.Winner == PlayerActor {
+		b.WriteString("You have won!\n")
+	} else {
+		b.WriteString("RoboDurrr has won!\n")
+	}
+	b.WriteString("\n******* New Hand *******\n\n")
+}
+
+// text describes the action; behind is true when someone acted before it.
+func (a ActionRecord) text(behind bool) string {
+	if a.Actor == AiActor {
+		switch a.Action {
+		case "bet":
+			return fmt.Sprintf("RoboDurrr has bet %d currency\n...Waiting for your action...", a.Amount)
+		case "check":
+			if behind {
+				return "RoboDurrr checked behind"
+			}
+			return "RoboDurrr checked"
+		case "call":
+			return "RoboDurrr has called"
+		default:
+			return "RoboDurrr has folded"
+		}
+	}
+	switch a.Action {
+	case "bet":
+		return fmt.Sprintf("You have bet %d currency", a.Amount)
+	case "check":
+		if behind {
+			return "You have checked behind"
+		}
+		return "You have checked"
+	case "call":
+		return fmt.Sprintf("You have called for %d currency", a.Amount)
+	default:
+		return "You have folded"
+	}
+}
@@ -0,0 +1,84 @@
+package kuhn
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHandHistory(t *testing.T) {
+	ai := NewKuhnTrainer(WithSeed(1))
+	ai.Train(10000)
+	var written bytes.Buffer
+	g := newGame(t, ai, WithGameSeed(2), WithHandHistory(&written))
+	g.BeginRound()
+	for i := 0; i < 60; i++ {
+		if i%3 == 0 {
+			g.Bet()
+		} else {
+			g.Check()
+		}
+	}
+
+	if len(g.History) != g.HandNumber-1 {
+		t.Fatalf("%d hands recorded after %d were finished", len(g.History), g.HandNumber-1)
+	}
+	playerStack := 10
+	for i, h := range g.History {
+		if h.Number != i+1 || h.PlayerPosition != i%2 || h.AiPosition != 1-i%2 {
+			t.Errorf("hand %d numbered %d with positions %d, %d", i+1, h.Number, h.PlayerPosition, h.AiPosition)
+		}
+		if h.PlayerDelta+h.AiDelta != 0 {
+			t.Errorf("hand %d deltas %d and %d are not zero-sum", h.Number, h.PlayerDelta, h.AiDelta)
+		}
+		if len(h.Actions) < 2 || len(h.Actions) > 3 {
+			t.Errorf("hand %d has %d actions", h.Number, len(h.Actions))
+		}
+		last := h.Actions[len(h.Actions)-1]
+		if h.Showdown == (last.Action == "fold") {
+			t.Errorf("hand %d ends with %s but showdown is %v", h.Number, last.Action, h.Showdown)
+		}
+		playerStack += h.PlayerDelta
+	}
+	// The current hand's ante is already out of the player's stack.
+	if playerStack-1 != g.PlayerStack {
+		t.Errorf("deltas add up to a stack of %d, the game has %d", playerStack-1, g.PlayerStack)
+	}
+
+	read, err := ReadJSONL(&written)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, g.History) {
+		t.Error("hands written as they finished differ from History")
+	}
+	if strings.Count(g.Log(), "New Hand") != len(g.History) {
+		t.Error("the log does not show every finished hand")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestHandHistoryErr(t *testing.T) {
+	ai := NewKuhnTrainer(WithSeed(1))
+	ai.Train(1000)
+	g := newGame(t, ai, WithGameSeed(2), WithHandHistory(failingWriter{}))
+	g.BeginRound()
+	for len(g.History) < 2 {
+		if err := g.Check(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.HandHistoryErr(); err == nil {
+		t.Error("a hand that could not be written was not reported")
+	}
+	if err := g.HandHistoryErr(); err != nil {
+		t.Errorf("the error was reported twice: %v", err)
+	}
+}
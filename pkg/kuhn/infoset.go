@@ -0,0 +1,125 @@
+package kuhn
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// History is a betting history packed into an integer: a 1 bit marking the
+// start, followed by a bit per action, 0 for a pass and 1 for a bet. The
+// empty history is 1 and "pb" is 0b101.
+type History uint32
+
+const EmptyHistory History = 1
+
+const (
+	passPass History = 0b100
+	// betBet is the last two actions of a history ending in a call.
+	betBet History = 0b11
+)
+
+func ParseHistory(s string) History {
+	h := EmptyHistory
+	for i := 0; i < len(s); i++ {
+		if s[i] == 'b' {
+			h = h.Append(int(Bet))
+		} else {
+			h = h.Append(int(Pass))
+		}
+	}
+	return h
+}
+
+// Append returns h followed by action a.
+func (h History) Append(a int) History {
+	return h<<1 | History(a)
+}
+
+func (h History) Len() int {
+	return bits.Len32(uint32(h)) - 1
+}
+
+// At returns the i-th action, counting from 0.
+func (h History) At(i int) int {
+	return int(h>>(h.Len()-1-i)) & 1
+}
+
+// prefix returns the first n actions of h.
+func (h History) prefix(n int) History {
+	return h >> (h.Len() - n)
+}
+
+func (h History) String() string {
+	var b strings.Builder
+	for i := 0; i < h.Len(); i++ {
+		b.WriteString(actionString(h.At(i)))
+	}
+	return b.String()
+}
+
+// InfoSetString is the NodeMap key of the information set of player holding
+// card after history.
+func InfoSetString(player int, card rune, history History) string {
+	return strconv.Itoa(player) + " " + string(card) + history.String()
+}
+
+// Indexer numbers information sets so the trainer can keep its nodes in a
+// slice instead of looking them up by string. Index must give every
+// information set of the game a different number below Size. With
+// WithWorkers every worker goroutine calls Index at once, so it must then be
+// safe for concurrent use, as PackedIndexer is.
+type Indexer interface {
+	Size() int
+	Index(player int, card rune, history History) int
+}
+
+// PackedIndexer packs the card's rank into the low four bits of the index
+// and the history above them. The player to act follows from the length of
+// the history, so it takes no bits.
+type PackedIndexer struct {
+	// maxLen is the longest history a player can act after.
+	maxLen int
+}
+
+// NewPackedIndexer indexes Kuhn poker for the number of players, where the
+// longest history anyone acts after is a pass from all but one player, a
+// bet and a call or fold from all but two.
+func NewPackedIndexer(players int) PackedIndexer {
+	return PackedIndexer{maxLen: 2*players - 2}
+}
+
+func (p PackedIndexer) Size() int {
+	return 2 << p.maxLen << 4
+}
+
+func (p PackedIndexer) Index(player int, card rune, history History) int {
+	return int(history)<<4 | GetCardRank(card)
+}
+
+// WithIndexer makes the trainer keep its nodes by the indexer's numbers. By
+// default it uses a PackedIndexer.
+func WithIndexer(ix Indexer) Option {
+	return func(k *KuhnTrainer) {
+		k.indexer = ix
+	}
+}
+
+// node returns the node for player holding card after history, creating it
+// when there is none. nodes holds the same nodes as NodeMap, by index, and
+// is filled from it on a miss.
+func (k *KuhnTrainer) node(player int, card rune, history History) *kuhnNode {
+	if k.indexer == nil {
+		k.indexer = NewPackedIndexer(k.config.Players)
+	}
+	if k.nodes == nil {
+		k.nodes = make([]*kuhnNode, k.indexer.Size())
+	}
+	i := k.indexer.Index(player, card, history)
+	if node := k.nodes[i]; node != nil {
+		return node
+	}
+	node := k.getOrCreateKuhnNode(InfoSetString(player, card, history), player)
+	k.nodes[i] = node
+	return node
+}
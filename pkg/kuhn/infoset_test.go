@@ -0,0 +1,99 @@
+package kuhn
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	for _, s := range []string{"", "p", "b", "pb", "bbp", "ppbpb"} {
+		h := ParseHistory(s)
+		if h.String() != s || h.Len() != len(s) {
+			t.Errorf("%q packs to %b, which reads back as %q of length %d", s, h, h.String(), h.Len())
+		}
+	}
+	if got := ParseHistory("p").Append(int(Bet)); got != ParseHistory("pb") {
+		t.Errorf("p then a bet is %b, want %b", got, ParseHistory("pb"))
+	}
+}
+
+func TestPackedIndexerIsUnique(t *testing.T) {
+	for _, players := range []int{2, 3} {
+		c := DefaultConfig()
+		c.Players = players
+		k := newTrainer(t, c, WithSeed(1))
+		k.Train(20000)
+		ix := NewPackedIndexer(players)
+		seen := make(map[int]string)
+		for infoSet, node := range k.NodeMap {
+			player, card, history := parseInfoSet(infoSet)
+			i := ix.Index(player, card, history)
+			if i < 0 || i >= ix.Size() {
+				t.Fatalf("%d players: %q has index %d, size %d", players, infoSet, i, ix.Size())
+			}
+			if other, ok := seen[i]; ok {
+				t.Errorf("%d players: %q and %q share index %d", players, infoSet, other, i)
+			}
+			seen[i] = infoSet
+			if k.nodes[i] != node {
+				t.Errorf("%d players: slot %d does not hold %q", players, i, infoSet)
+			}
+		}
+	}
+}
+
+// mapIndexer numbers information sets in the order they are first seen. It
+// is not safe for concurrent use, so it cannot train with WithWorkers.
+type mapIndexer map[string]int
+
+func (m mapIndexer) Size() int {
+	return 1000
+}
+
+func (m mapIndexer) Index(player int, card rune, history History) int {
+	key := InfoSetString(player, card, history)
+	if _, ok := m[key]; !ok {
+		m[key] = len(m)
+	}
+	return m[key]
+}
+
+func TestCustomIndexer(t *testing.T) {
+	packed := NewKuhnTrainer(WithSeed(1))
+	packed.Train(10000)
+	custom := NewKuhnTrainer(WithSeed(1), WithIndexer(mapIndexer{}))
+	custom.Train(10000)
+	if !bytes.Equal(snapshotBytes(t, &packed), snapshotBytes(t, &custom)) {
+		t.Error("a different indexer trained different nodes")
+	}
+
+	ix := mapIndexer{}
+	sampled := NewKuhnTrainer(WithSeed(1), WithIndexer(ix))
+	sampled.TrainOutcomeSampling(10000)
+	if len(ix) != len(sampled.NodeMap) {
+		t.Errorf("outcome sampling indexed %d of %d nodes", len(ix), len(sampled.NodeMap))
+	}
+}
+
+// Restoring a snapshot replaces NodeMap, and training must carry on with
+// the restored nodes rather than the ones indexed before.
+func TestIndexAfterRestore(t *testing.T) {
+	k := NewKuhnTrainer(WithSeed(1))
+	k.Train(1000)
+	saved := k.Snapshot()
+	k.Train(1000)
+	if err := k.Restore(saved); err != nil {
+		t.Fatal(err)
+	}
+	k.Train(1000)
+	for infoSet, node := range k.NodeMap {
+		if k.node(parseInfoSet(infoSet)) != node {
+			t.Errorf("%q is indexed to a stale node", infoSet)
+		}
+	}
+}
+
+// parseInfoSet reverses InfoSetString.
+func parseInfoSet(infoSet string) (int, rune, History) {
+	return int(infoSet[0] - '0'), rune(infoSet[2]), ParseHistory(infoSet[3:])
+}
@@ -0,0 +1,96 @@
+package kuhn
+
+import "github.com/pepperonirollz/cfr/pkg/game"
+
+// KuhnGame is Kuhn poker by the rules in Config, expressed as a game.Game so
+// it can be solved by the generic engine in pkg/cfr. Information set keys
+// match the ones KuhnTrainer stores in NodeMap.
+type KuhnGame struct {
+	Config
+}
+
+type kuhnState struct {
+	config  Config
+	players int
+	cards   []rune
+	history History
+}
+
+// NewKuhnGame plays two players over deck with an ante and bet of 1.
+func NewKuhnGame(deck []rune) KuhnGame {
+	c := DefaultConfig()
+	c.Deck = deck
+	return KuhnGame{Config: c}
+}
+
+// Game is the game the trainer solves.
+func (k *KuhnTrainer) Game() KuhnGame {
+	return KuhnGame{Config: k.config}
+}
+
+func (g KuhnGame) NumPlayers() int {
+	return g.Players
+}
+
+func (g KuhnGame) Root() game.State {
+	return kuhnState{config: g.Config, players: g.Players, history: EmptyHistory}
+}
+
+func (s kuhnState) IsTerminal() bool {
+	if len(s.cards) < s.players {
+		return false
+	}
+	_, terminal := s.config.payoffs(s.cards, s.history, s.players)
+	return terminal
+}
+
+func (s kuhnState) CurrentPlayer() int {
+	if len(s.cards) < s.players {
+		return game.Chance
+	}
+	return s.history.Len() % s.players
+}
+
+func (s kuhnState) LegalActions() []int {
+	return []int{int(Pass), int(Bet)}
+}
+
+func (s kuhnState) ChanceOutcomes() []game.Outcome {
+	var outcomes []game.Outcome
+	remaining := len(s.config.Deck) - len(s.cards)
+	for i, card := range s.config.Deck {
+		if !s.isDealt(card) {
+			outcomes = append(outcomes, game.Outcome{Action: i, Prob: 1.0 / float64(remaining)})
+		}
+	}
+	return outcomes
+}
+
+func (s kuhnState) Apply(action int) game.State {
+	next := s
+	if s.CurrentPlayer() == game.Chance {
+		next.cards = append(append([]rune(nil), s.cards...), s.config.Deck[action])
+	} else {
+		next.history = s.history.Append(action)
+	}
+	return next
+}
+
+func (s kuhnState) Utility(player int) float64 {
+	payoffs, _ := s.config.payoffs(s.cards, s.history, s.players)
+	return payoffs[player]
+}
+
+func (s kuhnState) InfoSetKey() string {
+	player := s.CurrentPlayer()
+	return InfoSetString(player, s.cards[player], s.history)
+}
+
+func (s kuhnState) isDealt(card rune) bool {
+	for _, c := range s.cards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
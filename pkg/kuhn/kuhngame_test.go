@@ -0,0 +1,115 @@
+package kuhn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+)
+
+func TestKuhnGameEquilibrium(t *testing.T) {
+	trainer := cfr.NewTrainer(NewKuhnGame([]rune{'J', 'Q', 'K'}))
+	trainer.Train(5000)
+
+	value := trainer.Value()
+	if math.Abs(value[0]+1.0/18) > 0.005 {
+		t.Errorf("player 1 value = %.4f, want -1/18", value[0])
+	}
+	if value[0]+value[1] != 0 {
+		t.Errorf("values %v are not zero-sum", value)
+	}
+
+	bet := func(infoSet string) float64 {
+		return trainer.AvgStrategy(infoSet, 2)[Bet]
+	}
+	alpha := bet("0 J")
+	if alpha > 1.0/3+0.05 {
+		t.Errorf("player 1 bluffs J with %.3f, want at most 1/3", alpha)
+	}
+	// The second player's equilibrium strategy is unique.
+	tests := []struct {
+		infoSet string
+		want    float64
+	}{
+		{"0 K", 3 * alpha},
+		{"0 Q", 0},
+		{"0 Qpb", alpha + 1.0/3},
+		{"0 Jpb", 0},
+		{"0 Kpb", 1},
+		{"1 Jp", 1.0 / 3},
+		{"1 Jb", 0},
+		{"1 Qp", 0},
+		{"1 Qb", 1.0 / 3},
+		{"1 Kp", 1},
+		{"1 Kb", 1},
+	}
+	for _, tc := range tests {
+		if got := bet(tc.infoSet); math.Abs(got-tc.want) > 0.05 {
+			t.Errorf("%s: bets %.3f, want %.3f", tc.infoSet, got, tc.want)
+		}
+	}
+}
+
+func TestExternalSamplingMatchesVanilla(t *testing.T) {
+	g := NewKuhnGame([]rune{'J', 'Q', 'K'})
+	vanilla := cfr.NewTrainer(g)
+	vanilla.Train(5000)
+	sampled := cfr.NewTrainer(g, cfr.WithSeed(1))
+	sampled.TrainExternalSampling(200000)
+
+	if value := sampled.Value(); math.Abs(value[0]+1.0/18) > 0.01 {
+		t.Errorf("external sampling player 1 value = %.4f, want -1/18", value[0])
+	}
+	// Player 1's equilibria form a family, so only compare the information
+	// sets every equilibrium agrees on.
+	for _, infoSet := range []string{"0 Q", "0 Jpb", "0 Kpb", "1 Jp", "1 Jb", "1 Qp", "1 Qb", "1 Kp", "1 Kb"} {
+		want := vanilla.AvgStrategy(infoSet, 2)
+		got := sampled.AvgStrategy(infoSet, 2)
+		if math.Abs(got[Bet]-want[Bet]) > 0.05 {
+			t.Errorf("%s: external sampling bets %.3f, vanilla %.3f", infoSet, got[Bet], want[Bet])
+		}
+	}
+}
+
+func TestExternalSamplingSameSeedSameNodes(t *testing.T) {
+	g := NewKuhnGame([]rune{'J', 'Q', 'K'})
+	a := cfr.NewTrainer(g, cfr.WithSeed(3))
+	a.TrainExternalSampling(10000)
+	b := cfr.NewTrainer(g, cfr.WithSeed(3))
+	b.TrainExternalSampling(10000)
+	for infoSet, node := range a.NodeMap {
+		if node.String() != b.NodeMap[infoSet].String() {
+			t.Errorf("%s differs between two runs with the same seed", infoSet)
+		}
+	}
+}
+
+// Kuhn's equilibrium with alpha = 0 cannot be exploited, and the generic
+// engine must drive its own average strategy towards one.
+func TestExploitability(t *testing.T) {
+	bets := map[string]float64{
+		"0 J": 0, "0 Q": 0, "0 K": 0, "0 Jpb": 0, "0 Qpb": 1.0 / 3, "0 Kpb": 1,
+		"1 Jp": 1.0 / 3, "1 Jb": 0, "1 Qp": 0, "1 Qb": 1.0 / 3, "1 Kp": 1, "1 Kb": 1,
+	}
+	k := newTrainer(t, ClassicConfig())
+	for infoSet, bet := range bets {
+		node := newKuhnNode(0)
+		node.InfoSet = infoSet
+		node.StrategySum[Pass], node.StrategySum[Bet] = 1-bet, bet
+		k.NodeMap[infoSet] = node
+	}
+	if e := k.Exploitability(); math.Abs(e) > 1e-9 {
+		t.Errorf("the equilibrium is exploitable by %g milli-chips", e)
+	}
+	k.NodeMap["1 Qb"].StrategySum[Pass] = 0
+	if e := k.Exploitability(); e < 10 {
+		t.Errorf("always calling with Q is exploitable by %.3f milli-chips, want more", e)
+	}
+
+	trainer := cfr.NewTrainer(NewKuhnGame(ClassicConfig().Deck))
+	before := trainer.Exploitability()
+	trainer.Train(5000)
+	if after := trainer.Exploitability(); after > 0.005 || after >= before {
+		t.Errorf("exploitability went from %.4f to %.4f after 5000 iterations", before, after)
+	}
+}
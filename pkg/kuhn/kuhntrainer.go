@@ -2,82 +2,127 @@ package kuhn
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
-	"strconv"
+	"time"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+	"github.com/pepperonirollz/cfr/pkg/rng"
 )
 
 type KuhnTrainer struct {
 	numActions int
 	NodeMap    map[string]*kuhnNode
+	// Convergence holds the exploitability recorded by Train every
+	// exploitabilityInterval iterations.
+	Convergence            []ConvergencePoint
+	config                 Config
+	iterations             int
+	exploitabilityInterval int
+	variant                Variant
+	// discount exponents used by the Discounted variant
+	alpha, beta, gamma float64
+	// epsilon is the exploration outcome sampling mixes into the
+	// traverser's strategy.
+	epsilon float64
+	source  *rng.Source
+	rng     *rand.Rand
+	// checkpointPath is written every checkpointEvery iterations of Train.
+	checkpointPath  string
+	checkpointEvery int
+	// checkpointErr is the first checkpoint Train failed to write.
+	checkpointErr error
+	workers       int
+	vector        bool
+	// byRank lists the cards from lowest to highest, and rootReach and
+	// frames are vector form's buffers, all made by its first iteration.
+	byRank    []int
+	rootReach [2][]float64
+	frames    map[History]*vectorFrame
+	// nodes holds NodeMap's nodes by indexer's numbers.
+	indexer Indexer
+	nodes   []*kuhnNode
+	// shared is the parent's NodeMap when k is a parallel worker. Workers
+	// play its strategies and collect their sums in their own NodeMap.
+	shared map[string]*kuhnNode
 }
 
-type kuhnNode struct {
-	numActions  int
-	infoSet     string
-	regretSum   []float64
-	strategy    []float64
-	strategySum []float64
+type ConvergencePoint struct {
+	Iteration      int
+	Exploitability float64
 }
 
-func NewKuhnTrainer() KuhnTrainer {
-	return KuhnTrainer{
-		numActions: 2,
-		NodeMap:    make(map[string]*kuhnNode),
+type Option func(*KuhnTrainer)
+
+// WithSeed seeds the generator every training method deals and samples
+// from, so two trainers with the same seed and options produce identical
+// nodes.
+func WithSeed(seed int64) Option {
+	return func(k *KuhnTrainer) {
+		k.source = rng.NewSource(seed)
+		k.rng = rand.New(k.source)
 	}
 }
 
-func newKuhnNode(p int) *kuhnNode {
-	return &kuhnNode{
-		numActions:  2,
-		infoSet:     "",
-		regretSum:   make([]float64, 2),
-		strategy:    make([]float64, 2),
-		strategySum: make([]float64, 2),
+// WithRand makes the trainer deal and sample from r. Parallel workers seed
+// their own generators from it, so only the goroutine calling Train uses r.
+// The state of r cannot be saved, so checkpoints taken with it do not resume
+// identically; use WithSeed for that.
+func WithRand(r *rand.Rand) Option {
+	return func(k *KuhnTrainer) {
+		k.source = nil
+		k.rng = r
 	}
 }
 
-func (n *kuhnNode) getStrategy(realizationWeight float64) []float64 {
-
-	normalizingSum := 0.0
-	for i := 0; i < n.numActions; i++ {
-		if n.regretSum[i] > 0 {
-			n.strategy[i] = n.regretSum[i]
-		} else {
-			n.strategy[i] = 0
-		}
-		normalizingSum += n.strategy[i]
+// WithEpsilon sets the exploration outcome sampling uses, 0.6 by default.
+func WithEpsilon(epsilon float64) Option {
+	return func(k *KuhnTrainer) {
+		k.epsilon = epsilon
 	}
+}
 
-	if normalizingSum > 0 {
-		for i := 0; i < n.numActions; i++ {
-			n.strategy[i] /= float64(normalizingSum)
-		}
-	} else {
-		for i := 0; i < n.numActions; i++ {
-			n.strategy[i] = 1.0 / float64(n.numActions)
-		}
+// WithExploitabilityInterval makes Train measure exploitability every n
+// iterations and append it to Convergence.
+func WithExploitabilityInterval(n int) Option {
+	return func(k *KuhnTrainer) {
+		k.exploitabilityInterval = n
 	}
+}
 
-	for i := 0; i < n.numActions; i++ {
-		n.strategySum[i] += realizationWeight * n.strategy[i]
+// kuhnNode is a cfr.Node whose average strategy drops actions played less
+// than 0.1% of the time.
+type kuhnNode struct {
+	cfr.Node
+}
+
+func NewKuhnTrainer(opts ...Option) KuhnTrainer {
+	k := KuhnTrainer{
+		numActions: 2,
+		NodeMap:    make(map[string]*kuhnNode),
+		config:     DefaultConfig(),
+		epsilon:    0.6,
+		source:     rng.NewSource(time.Now().UnixNano()),
+	}
+	k.rng = rand.New(k.source)
+	for _, opt := range opts {
+		opt(&k)
 	}
+	return k
+}
 
-	return n.strategy
+func newKuhnNode(p int) *kuhnNode {
+	return &kuhnNode{Node: *cfr.NewNode("", 2)}
+}
+
+func (n *kuhnNode) getStrategy(realizationWeight float64) []float64 {
+	n.RegretMatching()
+	n.AccumulateStrategy(realizationWeight)
+	return n.Strategy
 }
 
 func (n *kuhnNode) GetAvgStrategy() []float64 {
-	avgStrategy := make([]float64, n.numActions)
-	var normalizingSum float64
-	for i := 0; i < n.numActions; i++ {
-		normalizingSum += n.strategySum[i]
-	}
-	for i := 0; i < n.numActions; i++ {
-		if normalizingSum > 0 {
-			avgStrategy[i] = n.strategySum[i] / normalizingSum
-		} else {
-			avgStrategy[i] = 1.0 / float64(n.numActions)
-		}
-	}
+	avgStrategy := n.Node.GetAvgStrategy()
 	for i, value := range avgStrategy {
 		if value < 0.001 {
 			avgStrategy[i] = 0
@@ -87,126 +132,215 @@ func (n *kuhnNode) GetAvgStrategy() []float64 {
 }
 
 func (n kuhnNode) String() string {
-	return fmt.Sprintf("%4s: %v", n.infoSet, n.GetAvgStrategy())
+	return fmt.Sprintf("%4s: %v", n.InfoSet, n.GetAvgStrategy())
 }
 
-func (k KuhnTrainer) Train(iterations int) {
-	cards := []rune{'2', '3', '4', '5', '6', '7', '8', '9', 'T', 'J', 'Q', 'K', 'A'}
-	util := 0.0
-	for i := 0; i < iterations; i++ {
-		Shuffle(cards)
-		util += k.cfr(cards, "", 1, 1)
+// Train runs iterations more iterations. A checkpoint it fails to write does
+// not stop training; CheckpointErr reports it afterwards.
+func (k *KuhnTrainer) Train(iterations int) {
+	if err := k.train(iterations); err != nil && k.checkpointErr == nil {
+		k.checkpointErr = err
+	}
+}
+
+func (k *KuhnTrainer) train(iterations int) error {
+	var checkpointErr error
+	cards := make([]rune, len(k.config.Deck))
+	reach := make([]float64, k.config.Players)
+	for p := range reach {
+		reach[p] = 1
+	}
+	n := 1
+	for done := 0; done < iterations; done += n {
+		if k.config.Players == 2 && !k.vector && k.workers > 1 && k.variant == Vanilla {
+			n = k.roundSize(iterations - done)
+		}
+		k.iterations += n
+		switch {
+		case k.config.Players > 2:
+			k.deal(cards)
+			k.cfrN(cards, EmptyHistory, reach)
+		case k.vector:
+			k.vectorIteration()
+		case k.workers > 1 && k.variant == Vanilla:
+			k.parallelDeals(n)
+		case k.workers > 1:
+			k.parallelSweep()
+		case k.variant == Vanilla:
+			k.deal(cards)
+			k.cfr(cards, EmptyHistory, 1, 1, allPlayers)
+		default:
+			k.sweepIteration()
+		}
+		if k.exploitabilityInterval > 0 && k.iterations%k.exploitabilityInterval == 0 {
+			k.Convergence = append(k.Convergence, ConvergencePoint{
+				Iteration:      k.iterations,
+				Exploitability: k.Exploitability(),
+			})
+		}
+		if k.checkpointEvery > 0 && k.iterations%k.checkpointEvery == 0 {
+			if err := k.checkpoint(); err != nil && checkpointErr == nil {
+				checkpointErr = err
+			}
+		}
+	}
+	return checkpointErr
+}
+
+// Report writes the value of the average strategy, every node and the
+// exploitability to w.
+func (k *KuhnTrainer) Report(w io.Writer) {
+	if k.config.Players > 2 {
+		fmt.Fprintln(w, "Expected values: ", k.Values())
+	} else {
+		value := k.Value()
+		fmt.Fprintln(w, "Expected value: ", value, "player 2: ", -value)
 	}
-	fmt.Println("Expected value: ", util/float64(iterations), "player 2: ", -1*util/float64(iterations))
 	for _, node := range k.NodeMap {
-		fmt.Println(node.String())
+		fmt.Fprintln(w, node.String())
 	}
-	fmt.Println("Num infosets: ", len(k.NodeMap))
+	fmt.Fprintln(w, "Num infosets: ", len(k.NodeMap))
+	fmt.Fprintf(w, "Exploitability: %.3f milli-chips per hand\n", k.Exploitability())
 }
 
-func Shuffle(cards []rune) {
-	rand.Shuffle(len(cards), func(i, j int) {
+// deal shuffles a fresh copy of the deck into cards, so each deal depends
+// only on the generator and not on the previous one.
+func (k *KuhnTrainer) deal(cards []rune) {
+	copy(cards, k.config.Deck)
+	Shuffle(cards, k.rng)
+}
+
+func Shuffle(cards []rune, r *rand.Rand) {
+	r.Shuffle(len(cards), func(i, j int) {
 		cards[i], cards[j] = cards[j], cards[i]
 	})
 }
 
-func (k *KuhnTrainer) cfr(cards []rune, history string, p0 float64, p1 float64) float64 {
-	plays := len(history)
+// cfr returns the value of history for the player to act. Only traverser's
+// nodes are updated, or every node when traverser is allPlayers.
+func (k *KuhnTrainer) cfr(cards []rune, history History, p0 float64, p1 float64, traverser int) float64 {
+	plays := history.Len()
 	player := plays % 2
 	opponent := 1 - player
-	terminalStatePayoff := terminalStatePayoff(cards, plays, history, player, opponent)
-	if terminalStatePayoff != 0 {
-		return float64(terminalStatePayoff)
+	if payoff, ok := k.config.historyPayoff(cards, history, player, opponent); ok {
+		return float64(payoff)
 	}
-	infoSet := strconv.Itoa(player) + " " + string(cards[player]) + history
-	node := k.getOrCreateKuhnNode(infoSet, player)
+	node := k.node(player, cards[player], history)
+	updating := traverser == allPlayers || traverser == player
 
+	realizationWeight := 0.0
+	if updating && player == 0 {
+		realizationWeight = p0 * k.strategyWeight()
+	} else if updating {
+		realizationWeight = p1 * k.strategyWeight()
+	}
 	var strategy []float64
-	if player == 0 {
-		strategy = node.getStrategy(p0)
+	if k.variant != Vanilla || k.shared != nil {
+		// fixed for the whole pass by sweepIteration or runWorkers
+		strategy = node.Strategy
+		node.AccumulateStrategy(realizationWeight)
 	} else {
-		strategy = node.getStrategy(p1)
+		strategy = node.getStrategy(realizationWeight)
 	}
 
-	util := make([]float64, node.numActions)
+	// Kuhn has two actions, so the array stays off the heap
+	var util [2]float64
 	nodeUtil := 0.0
 
-	for i := 0; i < node.numActions; i++ {
-		var nextHistory string
-		if i == 0 {
-			nextHistory = history + "p"
-		} else {
-			nextHistory = history + "b"
-		}
+	for i := 0; i < node.NumActions(); i++ {
+		nextHistory := history.Append(i)
 		if player == 0 {
-			util[i] = -k.cfr(cards, nextHistory, p0*strategy[i], p1)
+			util[i] = -k.cfr(cards, nextHistory, p0*strategy[i], p1, traverser)
 		} else {
-			util[i] = -k.cfr(cards, nextHistory, p0, p1*strategy[i])
+			util[i] = -k.cfr(cards, nextHistory, p0, p1*strategy[i], traverser)
 		}
 
 		nodeUtil += strategy[i] * util[i]
 	}
 
-	for i := 0; i < node.numActions; i++ {
+	if !updating {
+		return nodeUtil
+	}
+	for i := 0; i < node.NumActions(); i++ {
 		regret := util[i] - nodeUtil
 
 		if player == 0 {
-			node.regretSum[i] += p1 * regret
+			node.RegretSum[i] += p1 * regret
 		} else {
-			node.regretSum[i] += p0 * regret
+			node.RegretSum[i] += p0 * regret
 		}
 	}
 	return nodeUtil
 }
 
-func terminalStatePayoff(cards []rune, plays int, history string, player int, opponent int) int {
-	if plays > 1 {
+// terminalStatePayoff is what player, who would act next, wins at history,
+// and false while the hand is not over.
+func (c Config) terminalStatePayoff(cards []rune, plays int, history string, player int, opponent int) (int, bool) {
+	return c.historyPayoff(cards, ParseHistory(history[:plays]), player, opponent)
+}
 
-		terminalPass := false
-		doubleBet := false
-		isPlayerCardHigher := false
-		if history[plays-1] == 'p' {
-			terminalPass = true
-		}
-		if history[plays-2:plays] == "bb" {
-			doubleBet = true
+// historyPayoff is terminalStatePayoff for a packed history.
+func (c Config) historyPayoff(cards []rune, history History, player int, opponent int) (int, bool) {
+	if history.Len() < 2 {
+		return 0, false
+	}
+	isPlayerCardHigher := GetCardRank(cards[player]) > GetCardRank(cards[opponent])
+	switch {
+	case history == passPass:
+		if isPlayerCardHigher {
+			return c.Ante, true
 		}
-		if GetCardRank(cards[player]) > GetCardRank(cards[opponent]) {
-			isPlayerCardHigher = true
+		return -c.Ante, true
+	case history&1 == History(Pass):
+		// the other player folded to a bet
+		return c.Ante, true
+	case history&3 == betBet:
+		if isPlayerCardHigher {
+			return c.Ante + c.BetSize, true
 		}
-		if terminalPass {
-			if history == "pp" {
-				if isPlayerCardHigher {
-					return 1
-				} else {
-					return -1
-				}
-			} else {
-				return 1
-			}
-
-		} else if doubleBet {
+		return -c.Ante - c.BetSize, true
+	}
+	return 0, false
+}
 
-			if isPlayerCardHigher {
-				return 2
-			} else {
-				return -2
-			}
-		}
+// clone returns a trainer with its own copy of every node, so it can keep
+// learning while other goroutines read k. It draws from a new generator
+// seeded from the clock.
+func (k *KuhnTrainer) clone() KuhnTrainer {
+	c := *k
+	c.NodeMap = make(map[string]*kuhnNode, len(k.NodeMap))
+	for infoSet, node := range k.NodeMap {
+		copied := newKuhnNode(0)
+		copied.InfoSet = node.InfoSet
+		copy(copied.RegretSum, node.RegretSum)
+		copy(copied.Strategy, node.Strategy)
+		copy(copied.StrategySum, node.StrategySum)
+		c.NodeMap[infoSet] = copied
 	}
-	return 0
+	c.nodes = nil
+	c.frames = nil
+	c.Convergence = append([]ConvergencePoint(nil), k.Convergence...)
+	c.source = rng.NewSource(time.Now().UnixNano())
+	c.rng = rand.New(c.source)
+	return c
 }
 
 func (k *KuhnTrainer) getOrCreateKuhnNode(infoSet string, player int) *kuhnNode {
 	node, ok := k.NodeMap[infoSet]
 	if !ok {
 		node = newKuhnNode(player)
-		node.infoSet = infoSet
+		node.InfoSet = infoSet
+		if shared, ok := k.shared[infoSet]; ok {
+			node.Strategy = shared.Strategy
+		}
 		k.NodeMap[infoSet] = node
 		return node
 	}
 	return node
 }
+
+// GetCardRank is the rank of a card, 2 up to 14 for the ace, or 0 for a rune
+// that is not a rank. Config.Validate rejects decks holding such a rune.
 func GetCardRank(r rune) int {
 	switch r {
 	case '2':
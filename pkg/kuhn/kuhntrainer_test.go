@@ -0,0 +1,79 @@
+package kuhn
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/snapshot"
+)
+
+func snapshotBytes(t *testing.T, k *KuhnTrainer) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := snapshot.Write(&buf, k.Snapshot(), snapshot.Binary); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSameSeedSameGame(t *testing.T) {
+	ai := NewKuhnTrainer(WithSeed(1))
+	ai.Train(10000)
+	play := func() string {
+		g := newGame(t, ai, WithGameSeed(5))
+		g.BeginRound()
+		for i := 0; i < 50; i++ {
+			if i%2 == 0 {
+				g.Check()
+			} else {
+				g.Bet()
+			}
+		}
+		return g.Log()
+	}
+	if play() != play() {
+		t.Error("two games with the same seed played out differently")
+	}
+}
+
+var benchmarkTrainers = []struct {
+	name string
+	opts []Option
+	// budget is the iterations BenchmarkConvergence trains for.
+	budget int
+}{
+	{"vanilla", nil, 100000},
+	{"cfr+", []Option{WithVariant(CFRPlus)}, 1000},
+	{"vector", []Option{WithVariant(CFRPlus), WithVectorForm()}, 1000},
+}
+
+// BenchmarkTrain times an iteration on the 13-card deck: a sampled deal for
+// vanilla CFR, and a sweep of every deal for CFR+, on its own and in vector
+// form.
+func BenchmarkTrain(b *testing.B) {
+	for _, bt := range benchmarkTrainers {
+		b.Run(bt.name, func(b *testing.B) {
+			k := NewKuhnTrainer(append([]Option{WithSeed(1)}, bt.opts...)...)
+			b.ReportAllocs()
+			b.ResetTimer()
+			k.Train(b.N)
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "iterations/s")
+		})
+	}
+}
+
+// BenchmarkConvergence trains a new trainer for each variant's budget per op
+// and reports the exploitability it reaches.
+func BenchmarkConvergence(b *testing.B) {
+	for _, bt := range benchmarkTrainers {
+		b.Run(bt.name, func(b *testing.B) {
+			var exploitability float64
+			for i := 0; i < b.N; i++ {
+				k := NewKuhnTrainer(append([]Option{WithSeed(1)}, bt.opts...)...)
+				k.Train(bt.budget)
+				exploitability = k.Exploitability()
+			}
+			b.ReportMetric(exploitability, "mchips/hand")
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package kuhn
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MatchEnd says why a match is over.
+type MatchEnd string
+
+const (
+	PlayerBust MatchEnd = "playerBust"
+	AiBust     MatchEnd = "aiBust"
+	HandLimit  MatchEnd = "handLimit"
+	Quit       MatchEnd = "quit"
+)
+
+var ErrNoRebuy = errors.New("kuhn: the player can only rebuy after going bust")
+
+// MatchSummary is the result of a match so far, or its final result once
+// the game is in the MatchOver state.
+type MatchSummary struct {
+	Hands int      `json:"hands"`
+	End   MatchEnd `json:"end,omitempty"`
+	// Winner is whoever is ahead, or "" when the player is even.
+	Winner      string `json:"winner,omitempty"`
+	PlayerStack int    `json:"playerStack"`
+	AiStack     int    `json:"aiStack"`
+	Rebuys      int    `json:"rebuys"`
+	// PlayerNet is the player's winnings over all the chips they bought in
+	// with, rebuys included.
+	PlayerNet int     `json:"playerNet"`
+	WinRate   float64 `json:"winRate"`
+}
+
+// WithStartingStack sets the chips each side starts with and a rebuy adds,
+// in place of the Config's Stack. A stack that cannot cover an ante and a
+// bet ends the match at once.
+func WithStartingStack(n int) GameOption {
+	return func(g *Game) {
+		g.StartingStack = n
+	}
+}
+
+// WithMaxHands ends the match after n hands.
+func WithMaxHands(n int) GameOption {
+	return func(g *Game) {
+		g.MaxHands = n
+	}
+}
+
+func (g *Game) Summary() MatchSummary {
+	stats := g.Stats()
+	summary := MatchSummary{
+		Hands:       len(g.History),
+		End:         g.MatchEnd,
+		PlayerStack: g.PlayerStack,
+		AiStack:     g.AiStack,
+		Rebuys:      g.Rebuys,
+		PlayerNet:   stats.Winnings,
+		WinRate:     stats.WinRate(),
+	}
+	if summary.PlayerNet > 0 {
+		summary.Winner = PlayerActor
+	} else if summary.PlayerNet < 0 {
+		summary.Winner = AiActor
+	}
+	return summary
+}
+
+// EndMatch stops the match and returns its summary. The hand in progress is
+// called off and everyone gets back what they put in the pot.
+func (g *Game) EndMatch() MatchSummary {
+	if g.GameState != MatchOver {
+		g.PlayerStack += g.Ante
+		g.AiStack += g.Ante
+		for _, a := range g.CurrentHand.Actions {
+			if a.Actor == PlayerActor {
+				g.PlayerStack += a.Amount
+			} else {
+				g.AiStack += a.Amount
+			}
+		}
+		g.Pot = 0
+		g.endMatch(Quit)
+	}
+	return g.Summary()
+}
+
+// Rebuy tops the bust player's stack up by StartingStack and deals the next
+// hand.
+func (g *Game) Rebuy() error {
+	if g.MatchEnd != PlayerBust {
+		return ErrNoRebuy
+	}
+	g.PlayerStack += g.StartingStack
+	g.Rebuys++
+	g.MatchEnd = ""
+	g.GameState = FirstAction
+	g.HandNumber = len(g.History) + 1
+	return g.BeginRound()
+}
+
+// matchOver reports whether the match has to end before the next hand.
+func (g *Game) matchOver() (MatchEnd, bool) {
+	minStack := Config{Ante: g.Ante, BetSize: g.BetSize}.minStack()
+	switch {
+	case g.PlayerStack < minStack:
+		return PlayerBust, true
+	case g.AiStack < minStack:
+		return AiBust, true
+	case g.MaxHands > 0 && len(g.History) >= g.MaxHands:
+		return HandLimit, true
+	}
+	return "", false
+}
+
+func (g *Game) endMatch(end MatchEnd) {
+	g.GameState = MatchOver
+	g.MatchEnd = end
+	g.CurrentHand = HandRecord{}
+	g.ActionHistory = ""
+	g.emit(Event{Type: MatchEndEvent, Winner: g.Summary().Winner})
+}
+
+func (s MatchSummary) String() string {
+	reason := map[MatchEnd]string{
+		PlayerBust: "you are out of chips",
+		AiBust:     "RoboDurrr is out of chips",
+		HandLimit:  "the last hand has been played",
+		Quit:       "you left the table",
+	}[s.End]
+	result := "you broke even"
+	if s.PlayerNet > 0 {
+		result = fmt.Sprintf("you won %d", s.PlayerNet)
+	} else if s.PlayerNet < 0 {
+		result = fmt.Sprintf("you lost %d", -s.PlayerNet)
+	}
+	return fmt.Sprintf("Match over after %d hands, %s: %s with %d rebuys", s.Hands, reason, result, s.Rebuys)
+}
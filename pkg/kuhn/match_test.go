@@ -0,0 +1,182 @@
+package kuhn
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+var (
+	matchAi     KuhnTrainer
+	matchAiOnce sync.Once
+)
+
+// newMatch deals the first hand of a game seeded with 2, unless opts seed it
+// otherwise.
+func newMatch(t *testing.T, opts ...GameOption) *Game {
+	t.Helper()
+	matchAiOnce.Do(func() {
+		matchAi = NewKuhnTrainer(WithSeed(1))
+		matchAi.Train(10000)
+	})
+	g := newGame(t, matchAi, append([]GameOption{WithGameSeed(2)}, opts...)...)
+	g.BeginRound()
+	return g
+}
+
+// newGame is NewGameWithAi for an AI the test knows to be playable.
+func newGame(t testing.TB, ai KuhnTrainer, opts ...GameOption) *Game {
+	t.Helper()
+	g, err := NewGameWithAi(ai, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// playOut alternates bets and checks until the match is over.
+func playOut(t *testing.T, g *Game) {
+	t.Helper()
+	for i := 0; g.GameState != MatchOver; i++ {
+		if i == 10000 {
+			t.Fatal("the match never ended")
+		}
+		if i%2 == 0 {
+			g.Bet()
+		} else {
+			g.Check()
+		}
+		if g.PlayerStack < 0 || g.AiStack < 0 {
+			t.Fatalf("stacks went negative: %d and %d", g.PlayerStack, g.AiStack)
+		}
+	}
+}
+
+func TestMatchEndsAtBustOut(t *testing.T) {
+	g := newMatch(t, WithStartingStack(3))
+	playOut(t, g)
+
+	if g.PlayerStack+g.AiStack != 6 || g.Pot != 0 {
+		t.Errorf("stacks %d and %d with %d in the pot", g.PlayerStack, g.AiStack, g.Pot)
+	}
+	summary := g.Summary()
+	switch summary.End {
+	case PlayerBust:
+		if g.PlayerStack >= g.Ante+g.BetSize || summary.Winner != AiActor {
+			t.Errorf("player bust with %d chips: %+v", g.PlayerStack, summary)
+		}
+	case AiBust:
+		if g.AiStack >= g.Ante+g.BetSize || summary.Winner != PlayerActor {
+			t.Errorf("AI bust with %d chips: %+v", g.AiStack, summary)
+		}
+	default:
+		t.Fatalf("match ended with %q", summary.End)
+	}
+	if summary.PlayerNet != g.PlayerStack-3 || summary.Hands != len(g.History) {
+		t.Errorf("summary %+v", summary)
+	}
+
+	hands := len(g.History)
+	g.Bet()
+	g.Check()
+	if len(g.History) != hands || g.GameState != MatchOver {
+		t.Error("hands were played after the match ended")
+	}
+}
+
+func TestRebuy(t *testing.T) {
+	g := newMatch(t, WithStartingStack(2))
+	if err := g.Rebuy(); !errors.Is(err, ErrNoRebuy) {
+		t.Errorf("rebuy in the middle of a match: %v", err)
+	}
+	for seed := int64(3); g.MatchEnd != PlayerBust; seed++ {
+		g = newMatch(t, WithStartingStack(2), WithGameSeed(seed))
+		playOut(t, g)
+	}
+	stack := g.PlayerStack
+	if err := g.Rebuy(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Rebuys != 1 || g.GameState == MatchOver || g.PlayerStack != stack+2-1 {
+		t.Errorf("after a rebuy: %d rebuys, state %v, stack %d", g.Rebuys, g.GameState, g.PlayerStack)
+	}
+	if g.HandNumber != len(g.History)+1 {
+		t.Errorf("hand %d after %d hands", g.HandNumber, len(g.History))
+	}
+	playOut(t, g)
+	if summary := g.Summary(); summary.PlayerNet != g.PlayerStack-2*2 {
+		t.Errorf("net %d with a stack of %d after one rebuy", summary.PlayerNet, g.PlayerStack)
+	}
+}
+
+func TestMatchHandLimit(t *testing.T) {
+	g := newMatch(t, WithStartingStack(100), WithMaxHands(5))
+	playOut(t, g)
+	if g.MatchEnd != HandLimit || len(g.History) != 5 || g.HandNumber != 5 {
+		t.Errorf("match ended with %q after %d hands, on hand %d", g.MatchEnd, len(g.History), g.HandNumber)
+	}
+	if g.PlayerStack+g.AiStack != 200 {
+		t.Errorf("stacks %d and %d", g.PlayerStack, g.AiStack)
+	}
+}
+
+func TestEndMatchReturnsThePot(t *testing.T) {
+	g := newMatch(t)
+	for i := 0; i < 7; i++ {
+		g.Check()
+	}
+	g.Bet()
+	summary := g.EndMatch()
+	if g.GameState != MatchOver || summary.End != Quit {
+		t.Fatalf("state %v after EndMatch: %+v", g.GameState, summary)
+	}
+	if g.PlayerStack+g.AiStack != 20 || g.Pot != 0 {
+		t.Errorf("stacks %d and %d with %d in the pot", g.PlayerStack, g.AiStack, g.Pot)
+	}
+	if summary.PlayerNet != g.PlayerStack-10 {
+		t.Errorf("net %d with a stack of %d", summary.PlayerNet, g.PlayerStack)
+	}
+}
+
+// TestOnlineLearning plays games that learn side by side against one AI, as
+// the server does. Each game's nodes must change and the AI's must not. Run
+// it with -race.
+func TestOnlineLearning(t *testing.T) {
+	newMatch(t)
+	before := matchAi.Snapshot()
+
+	games := make([]*Game, 4)
+	var wg sync.WaitGroup
+	for i := range games {
+		g := newMatch(t, WithOnlineLearning(), WithGameSeed(int64(i)), WithStartingStack(100), WithMaxHands(50))
+		games[i] = g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; g.GameState != MatchOver; n++ {
+				if n%3 == 0 {
+					g.Bet()
+				} else {
+					g.Check()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, g := range games {
+		changed := false
+		for infoSet, node := range g.Ai.NodeMap {
+			if old, ok := matchAi.NodeMap[infoSet]; !ok || !reflect.DeepEqual(node.RegretSum, old.RegretSum) {
+				changed = true
+			}
+		}
+		if !changed {
+			t.Errorf("game %d learned nothing from %d hands", i, len(g.History))
+		}
+	}
+	if !reflect.DeepEqual(matchAi.Snapshot(), before) {
+		t.Error("the games changed the AI they were started from")
+	}
+}
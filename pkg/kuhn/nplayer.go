@@ -0,0 +1,136 @@
+package kuhn
+
+import "strings"
+
+// ThreePlayerConfig is three-player Kuhn poker over J, Q, K, A. With more
+// than two players everyone antes and acts in turn; once someone bets, each
+// of the others calls or folds in turn, and the highest card among the
+// bettor and callers takes the pot.
+func ThreePlayerConfig() Config {
+	c := DefaultConfig()
+	c.Deck = []rune{'J', 'Q', 'K', 'A'}
+	c.Players = 3
+	return c
+}
+
+// Players is how many players the trainer is solving for.
+func (k *KuhnTrainer) Players() int {
+	return k.config.Players
+}
+
+// cfrN is cfr for any number of players. It returns every player's value of
+// history, given the probability reach[p] that each player p plays to it.
+func (k *KuhnTrainer) cfrN(cards []rune, history History, reach []float64) []float64 {
+	if payoffs, ok := k.config.payoffs(cards, history, k.config.Players); ok {
+		return payoffs
+	}
+	player := history.Len() % k.config.Players
+	node := k.node(player, cards[player], history)
+	strategy := node.getStrategy(reach[player])
+
+	util := make([][]float64, node.NumActions())
+	nodeUtil := make([]float64, k.config.Players)
+	for a := 0; a < node.NumActions(); a++ {
+		nextReach := append([]float64(nil), reach...)
+		nextReach[player] *= strategy[a]
+		util[a] = k.cfrN(cards, history.Append(a), nextReach)
+		for p := range nodeUtil {
+			nodeUtil[p] += strategy[a] * util[a][p]
+		}
+	}
+
+	counterfactualReach := 1.0
+	for p, r := range reach {
+		if p != player {
+			counterfactualReach *= r
+		}
+	}
+	for a := 0; a < node.NumActions(); a++ {
+		node.RegretSum[a] += counterfactualReach * (util[a][player] - nodeUtil[player])
+	}
+	return nodeUtil
+}
+
+// payoffs returns what each of the first players holding cards wins once
+// history is over, and false while it is not.
+func (c Config) payoffs(cards []rune, history History, players int) ([]float64, bool) {
+	plays := history.Len()
+	bet := -1
+	for i := 0; i < plays && bet < 0; i++ {
+		if history.At(i) == int(Bet) {
+			bet = i
+		}
+	}
+	if bet < 0 && plays < players || bet >= 0 && plays < bet+players {
+		return nil, false
+	}
+	payoffs := make([]float64, players)
+	winner := -1
+	pot := 0
+	for p := 0; p < players; p++ {
+		in := c.Ante
+		contending := true
+		if bet >= 0 {
+			// everyone acts once from the bet on, the bettor first
+			bettor := bet % players
+			contending = history.At(bet+(p-bettor+players)%players) == int(Bet)
+			if contending {
+				in += c.BetSize
+			}
+		}
+		payoffs[p] = -float64(in)
+		pot += in
+		if contending && (winner < 0 || GetCardRank(cards[p]) > GetCardRank(cards[winner])) {
+			winner = p
+		}
+	}
+	payoffs[winner] += float64(pot)
+	return payoffs, true
+}
+
+// Values is what each player wins per hand when everyone plays the average
+// strategy in NodeMap.
+func (k *KuhnTrainer) Values() []float64 {
+	deals := k.deals()
+	values := make([]float64, k.config.Players)
+	for _, cards := range deals {
+		for p, v := range k.profileValues(cards, EmptyHistory) {
+			values[p] += v / float64(len(deals))
+		}
+	}
+	return values
+}
+
+func (k *KuhnTrainer) profileValues(cards []rune, history History) []float64 {
+	if payoffs, ok := k.config.payoffs(cards, history, k.config.Players); ok {
+		return payoffs
+	}
+	player := history.Len() % k.config.Players
+	strategy := k.avgStrategy(InfoSetString(player, cards[player], history))
+	values := make([]float64, k.config.Players)
+	for a, prob := range strategy {
+		for p, v := range k.profileValues(cards, history.Append(a)) {
+			values[p] += prob * v
+		}
+	}
+	return values
+}
+
+// deals lists every way of giving each player a different card.
+func (k *KuhnTrainer) deals() [][]rune {
+	var deals [][]rune
+	var deal func(cards []rune)
+	deal = func(cards []rune) {
+		if len(cards) == k.config.Players {
+			deals = append(deals, append([]rune(nil), cards...))
+			return
+		}
+		for _, card := range k.config.Deck {
+			if !strings.ContainsRune(string(cards), card) {
+				deal(append(cards, card))
+			}
+		}
+	}
+	deal(make([]rune, 0, k.config.Players))
+	return deals
+}
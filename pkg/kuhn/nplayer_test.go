@@ -0,0 +1,84 @@
+package kuhn
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// With two players the general payoffs must agree with terminalStatePayoff,
+// which is from the point of view of the player to act.
+func TestPayoffsMatchTwoPlayer(t *testing.T) {
+	c := Config{Deck: []rune{'J', 'Q', 'K'}, Ante: 2, BetSize: 3}
+	histories := []string{"", "p", "b", "pp", "pb", "bp", "bb", "pbp", "pbb"}
+	for _, c0 := range c.Deck {
+		for _, c1 := range c.Deck {
+			if c0 == c1 {
+				continue
+			}
+			cards := []rune{c0, c1}
+			for _, h := range histories {
+				player := len(h) % 2
+				want, terminal := c.terminalStatePayoff(cards, len(h), h, player, 1-player)
+				payoffs, ok := c.payoffs(cards, ParseHistory(h), 2)
+				if ok != terminal {
+					t.Errorf("%c%c %q: terminal %v, want %v", c0, c1, h, ok, terminal)
+					continue
+				}
+				if ok && (payoffs[player] != float64(want) || payoffs[1-player] != -float64(want)) {
+					t.Errorf("%c%c %q: payoffs %v, want %d for player %d", c0, c1, h, payoffs, want, player)
+				}
+			}
+		}
+	}
+}
+
+func TestThreePlayerPayoffs(t *testing.T) {
+	c := ThreePlayerConfig()
+	tests := []struct {
+		cards   string
+		history string
+		want    []float64
+	}{
+		{"JQK", "ppp", []float64{-1, -1, 2}},
+		{"AQK", "bpp", []float64{2, -1, -1}},
+		{"JQK", "bbp", []float64{-2, 3, -1}},
+		{"JQA", "pbpb", []float64{-2, 3, -1}},
+		{"KJA", "ppbbb", []float64{-2, -2, 4}},
+		{"KJA", "ppbbp", []float64{-2, -1, 3}},
+		{"JQK", "pb", nil},
+		{"JQK", "ppbp", nil},
+	}
+	for _, tc := range tests {
+		got, ok := c.payoffs([]rune(tc.cards), ParseHistory(tc.history), 3)
+		if ok != (tc.want != nil) || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s %q: payoffs %v %v, want %v", tc.cards, tc.history, got, ok, tc.want)
+		}
+	}
+}
+
+func TestThreePlayerTraining(t *testing.T) {
+	k := newTrainer(t, ThreePlayerConfig(), WithSeed(1))
+	k.Train(100000)
+
+	// 12 betting histories a player can act after, with each of 4 cards
+	if len(k.NodeMap) != 48 {
+		t.Errorf("%d information sets, want 48", len(k.NodeMap))
+	}
+	values := k.Values()
+	if sum := values[0] + values[1] + values[2]; math.Abs(sum) > 1e-9 {
+		t.Errorf("values %v sum to %g", values, sum)
+	}
+	if e := k.Exploitability(); e > 3 {
+		t.Errorf("exploitability %.3f milli-chips, want at most 3", e)
+	}
+}
+
+// The N-player values must match the two-player ones.
+func TestValuesMatchTwoPlayer(t *testing.T) {
+	k := newTrainer(t, ClassicConfig(), WithSeed(1))
+	k.Train(1000)
+	if got, want := k.Values()[0], k.Value(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Values()[0] = %g, Value() = %g", got, want)
+	}
+}
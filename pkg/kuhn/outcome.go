@@ -0,0 +1,134 @@
+package kuhn
+
+// TrainOutcomeSampling runs outcome-sampling Monte Carlo CFR. Each iteration
+// deals one hand and samples a single path through it, exploring the
+// traverser's actions with probability epsilon, and corrects the regret and
+// strategy updates for how likely that path was to be sampled. The traverser
+// alternates between iterations.
+func (k *KuhnTrainer) TrainOutcomeSampling(iterations int) {
+	cards := make([]rune, len(k.config.Deck))
+	for i := 0; i < iterations; i++ {
+		k.deal(cards)
+		k.outcomeSampling(cards, EmptyHistory, k.iterations%2, 1, 1, 1)
+		k.iterations++
+	}
+}
+
+// ObserveHand is outcome sampling for a single hand that was actually
+// played, so a bot can keep learning from its games one hand at a time.
+// cards and history describe the finished hand and player is the seat the bot
+// held. The bot's actions are assumed to have been drawn from its average
+// strategy, the way Game plays them, while the opponent's are taken as
+// on-policy samples, so they need no correction.
+func (k *KuhnTrainer) ObserveHand(cards []rune, history string, player int) {
+	played := ParseHistory(history)
+	plays := played.Len()
+	if _, terminal := k.config.historyPayoff(cards, played, plays%2, 1-plays%2); !terminal {
+		return
+	}
+	k.observe(cards, played, 0, player, 1, 1)
+}
+
+// outcomeSampling returns the sampled value of history for traverser.
+// myReach and oppReach are the traverser's and the opponent's contributions
+// to reaching history, and sampleReach is the probability of sampling it.
+func (k *KuhnTrainer) outcomeSampling(cards []rune, history History, traverser int, myReach, oppReach, sampleReach float64) float64 {
+	player := history.Len() % 2
+	opponent := 1 - player
+	if payoff, ok := k.config.historyPayoff(cards, history, player, opponent); ok {
+		if player != traverser {
+			return -float64(payoff)
+		}
+		return float64(payoff)
+	}
+	node := k.node(player, cards[player], history)
+	node.RegretMatching()
+	strategy := append([]float64(nil), node.Strategy...)
+
+	sampling := strategy
+	if player == traverser {
+		sampling = make([]float64, node.NumActions())
+		for i := range sampling {
+			sampling[i] = k.epsilon/float64(node.NumActions()) + (1-k.epsilon)*strategy[i]
+		}
+	}
+	a := k.sampleAction(sampling)
+
+	var childValue float64
+	if player == traverser {
+		childValue = k.outcomeSampling(cards, history.Append(a), traverser, myReach*strategy[a], oppReach, sampleReach*sampling[a])
+	} else {
+		childValue = k.outcomeSampling(cards, history.Append(a), traverser, myReach, oppReach*strategy[a], sampleReach*sampling[a])
+	}
+
+	value := strategy[a] * childValue / sampling[a]
+	if player == traverser {
+		for i := 0; i < node.NumActions(); i++ {
+			actionValue := 0.0
+			if i == a {
+				actionValue = childValue / sampling[a]
+			}
+			node.RegretSum[i] += (actionValue - value) * oppReach / sampleReach
+		}
+	} else {
+		// oppReach is this player's own reach, so this is the usual average
+		// strategy update divided by the chance of sampling it.
+		for i := 0; i < node.NumActions(); i++ {
+			node.StrategySum[i] += oppReach * strategy[i] / sampleReach
+		}
+	}
+	return value
+}
+
+// observe walks the played history from position plays and returns the
+// sampled value of it for player, updating player's nodes on the way back.
+func (k *KuhnTrainer) observe(cards []rune, played History, plays int, player int, myReach, sampleReach float64) float64 {
+	toAct := plays % 2
+	history := played.prefix(plays)
+	if payoff, ok := k.config.historyPayoff(cards, history, toAct, 1-toAct); ok {
+		if toAct != player {
+			return -float64(payoff)
+		}
+		return float64(payoff)
+	}
+	a := played.At(plays)
+	if toAct != player {
+		return k.observe(cards, played, plays+1, player, myReach, sampleReach)
+	}
+
+	// a node never visited plays uniformly, so looking it up, and creating
+	// it if need be, gives the same strategy as avgStrategy
+	node := k.node(player, cards[player], history)
+	avg := node.playedStrategy()
+	if avg[a] == 0 {
+		// not an action the average strategy plays, so there is no sampling
+		// probability to correct by
+		return k.observe(cards, played, plays+1, player, myReach, sampleReach)
+	}
+	node.RegretMatching()
+	strategy := append([]float64(nil), node.Strategy...)
+
+	childValue := k.observe(cards, played, plays+1, player, myReach*strategy[a], sampleReach*avg[a])
+	value := strategy[a] * childValue / avg[a]
+	for i := 0; i < node.NumActions(); i++ {
+		actionValue := 0.0
+		if i == a {
+			actionValue = childValue / avg[a]
+		}
+		node.RegretSum[i] += (actionValue - value) / sampleReach
+		node.StrategySum[i] += myReach * strategy[i] / sampleReach
+	}
+	return value
+}
+
+func (k *KuhnTrainer) sampleAction(strategy []float64) int {
+	r := k.rng.Float64()
+	cumulativeProbability := 0.0
+	for i, p := range strategy {
+		cumulativeProbability += p
+		if r < cumulativeProbability {
+			return i
+		}
+	}
+	return len(strategy) - 1
+}
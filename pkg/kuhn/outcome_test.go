@@ -0,0 +1,30 @@
+package kuhn
+
+import "testing"
+
+func TestOutcomeSamplingConverges(t *testing.T) {
+	trainer := NewKuhnTrainer(WithSeed(1))
+	trainer.TrainOutcomeSampling(300000)
+	if e := trainer.Exploitability(); e > 15 {
+		t.Errorf("exploitability after outcome sampling = %.2f milli-chips, want under 15", e)
+	}
+}
+
+func TestObserveHandOnlyUpdatesTheBot(t *testing.T) {
+	trainer := NewKuhnTrainer(WithSeed(1))
+	trainer.Train(10000)
+	before := make(map[string][]float64)
+	for infoSet, node := range trainer.NodeMap {
+		before[infoSet] = append([]float64(nil), node.RegretSum...)
+	}
+
+	// The bot sat second with a king, was bet into and called.
+	trainer.ObserveHand([]rune{'7', 'K'}, "bb", 1)
+
+	for infoSet, node := range trainer.NodeMap {
+		changed := node.RegretSum[0] != before[infoSet][0] || node.RegretSum[1] != before[infoSet][1]
+		if changed != (infoSet == "1 Kb") {
+			t.Errorf("%s changed = %v", infoSet, changed)
+		}
+	}
+}
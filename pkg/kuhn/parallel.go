@@ -0,0 +1,136 @@
+package kuhn
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// dealsPerWorker is how many deals each worker plays per round of parallel
+// vanilla training. Strategies only change between rounds.
+const dealsPerWorker = 64
+
+// WithWorkers makes Train spread its deals across n goroutines. Workers play
+// the strategies fixed at the start of each round and sum their regrets in
+// private buffers, which are merged into NodeMap in worker order, so a seeded
+// run is still reproducible for a given n.
+func WithWorkers(n int) Option {
+	return func(k *KuhnTrainer) {
+		k.workers = n
+	}
+}
+
+// roundSize is how many vanilla iterations the next parallel round runs. A
+// round never crosses an exploitability or checkpoint boundary.
+func (k *KuhnTrainer) roundSize(remaining int) int {
+	n := k.workers * dealsPerWorker
+	if remaining < n {
+		n = remaining
+	}
+	for _, every := range []int{k.exploitabilityInterval, k.checkpointEvery} {
+		if every > 0 && every-k.iterations%every < n {
+			n = every - k.iterations%every
+		}
+	}
+	return n
+}
+
+// parallelDeals plays n sampled deals across the workers and returns the sum
+// of player 1's values.
+func (k *KuhnTrainer) parallelDeals(n int) float64 {
+	return k.runWorkers(func(w *KuhnTrainer, i int) float64 {
+		deals := n / k.workers
+		if i < n%k.workers {
+			deals++
+		}
+		cards := make([]rune, len(w.config.Deck))
+		util := 0.0
+		for d := 0; d < deals; d++ {
+			w.deal(cards)
+			util += w.cfr(cards, EmptyHistory, 1, 1, allPlayers)
+		}
+		return util
+	})
+}
+
+// parallelSweep is sweepIteration with each pass's deals split across the
+// workers.
+func (k *KuhnTrainer) parallelSweep() float64 {
+	var deals [][2]rune
+	for i, c0 := range k.config.Deck {
+		for j, c1 := range k.config.Deck {
+			if i != j {
+				deals = append(deals, [2]rune{c0, c1})
+			}
+		}
+	}
+	util := 0.0
+	for traverser := 0; traverser < 2; traverser++ {
+		value := k.runWorkers(func(w *KuhnTrainer, i int) float64 {
+			cards := make([]rune, 2)
+			util := 0.0
+			for d := i; d < len(deals); d += k.workers {
+				cards[0], cards[1] = deals[d][0], deals[d][1]
+				util += w.cfr(cards, EmptyHistory, 1, 1, traverser)
+			}
+			return util
+		})
+		if traverser == 0 {
+			util = value / float64(len(deals))
+		}
+	}
+	k.discount()
+	return util
+}
+
+// runWorkers fixes every strategy, runs play on each worker concurrently and
+// merges their buffers into NodeMap. It returns the sum of play's results.
+func (k *KuhnTrainer) runWorkers(play func(w *KuhnTrainer, i int) float64) float64 {
+	for _, node := range k.NodeMap {
+		node.RegretMatching()
+	}
+	workers := make([]*KuhnTrainer, k.workers)
+	for i := range workers {
+		workers[i] = k.newWorker(k.rng.Int63())
+	}
+	utils := make([]float64, len(workers))
+	var wg sync.WaitGroup
+	for i, w := range workers {
+		wg.Add(1)
+		go func(i int, w *KuhnTrainer) {
+			defer wg.Done()
+			utils[i] = play(w, i)
+		}(i, w)
+	}
+	wg.Wait()
+	util := 0.0
+	for i, w := range workers {
+		k.merge(w)
+		util += utils[i]
+	}
+	return util
+}
+
+// newWorker returns a trainer that reads k's nodes but keeps its regret and
+// strategy sums in its own NodeMap.
+func (k *KuhnTrainer) newWorker(seed int64) *KuhnTrainer {
+	return &KuhnTrainer{
+		numActions: k.numActions,
+		NodeMap:    make(map[string]*kuhnNode),
+		config:     k.config,
+		indexer:    k.indexer,
+		iterations: k.iterations,
+		variant:    k.variant,
+		rng:        rand.New(rand.NewSource(seed)),
+		shared:     k.NodeMap,
+	}
+}
+
+func (k *KuhnTrainer) merge(w *KuhnTrainer) {
+	for infoSet, delta := range w.NodeMap {
+		node := k.getOrCreateKuhnNode(infoSet, 0)
+		for i := 0; i < node.NumActions(); i++ {
+			node.RegretSum[i] += delta.RegretSum[i]
+			node.StrategySum[i] += delta.StrategySum[i]
+		}
+	}
+}
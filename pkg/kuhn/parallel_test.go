@@ -0,0 +1,44 @@
+package kuhn
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestParallelMatchesSerialExploitability(t *testing.T) {
+	const iterations = 1000000
+	serial := NewKuhnTrainer(WithSeed(1))
+	serial.Train(iterations)
+	parallel := NewKuhnTrainer(WithSeed(1), WithWorkers(4))
+	parallel.Train(iterations)
+
+	t.Logf("exploitability after %d deals: serial %.3f, 4 workers %.3f milli-chips per hand",
+		iterations, serial.Exploitability(), parallel.Exploitability())
+	if parallel.Exploitability() > 2*serial.Exploitability()+0.5 {
+		t.Errorf("parallel exploitability %.3f, serial %.3f", parallel.Exploitability(), serial.Exploitability())
+	}
+}
+
+func TestParallelSweepMatchesSerial(t *testing.T) {
+	serial := NewKuhnTrainer(WithVariant(CFRPlus))
+	serial.Train(100)
+	parallel := NewKuhnTrainer(WithVariant(CFRPlus), WithWorkers(4))
+	parallel.Train(100)
+
+	// Only the order regrets are summed in differs.
+	if diff := math.Abs(parallel.Exploitability() - serial.Exploitability()); diff > 1e-6 {
+		t.Errorf("parallel CFR+ exploitability %.6f, serial %.6f", parallel.Exploitability(), serial.Exploitability())
+	}
+}
+
+func TestParallelSameSeedSameNodes(t *testing.T) {
+	train := func() *KuhnTrainer {
+		k := NewKuhnTrainer(WithSeed(3), WithWorkers(4))
+		k.Train(20000)
+		return &k
+	}
+	if !bytes.Equal(snapshotBytes(t, train()), snapshotBytes(t, train())) {
+		t.Error("two parallel runs with the same seed and workers produced different nodes")
+	}
+}
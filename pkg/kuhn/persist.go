@@ -0,0 +1,85 @@
+package kuhn
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/pepperonirollz/cfr/pkg/rng"
+	"github.com/pepperonirollz/cfr/pkg/snapshot"
+)
+
+const snapshotGame = "kuhn"
+
+// Snapshot captures NodeMap in information set order.
+func (k *KuhnTrainer) Snapshot() *snapshot.Snapshot {
+	s := &snapshot.Snapshot{
+		Version:    snapshot.Version,
+		Game:       snapshotGame,
+		Config:     k.config.snapshotString(),
+		Iterations: k.iterations,
+	}
+	if k.source != nil {
+		s.Seed, s.RNGDraws = k.source.State()
+	}
+	for _, node := range k.NodeMap {
+		s.Nodes = append(s.Nodes, node.SnapshotNode())
+	}
+	sort.Slice(s.Nodes, func(i, j int) bool {
+		return s.Nodes[i].InfoSet < s.Nodes[j].InfoSet
+	})
+	return s
+}
+
+// Restore replaces NodeMap with the nodes in s, which must have been
+// trained for the same deck, stakes and number of players as k.
+func (k *KuhnTrainer) Restore(s *snapshot.Snapshot) error {
+	if s.Game != snapshotGame {
+		return fmt.Errorf("kuhn: snapshot is for %q", s.Game)
+	}
+	config := s.Config
+	if s.Version < 3 {
+		// the default deck was the only one before version 3
+		config = DefaultConfig().snapshotString()
+	}
+	if want := k.config.snapshotString(); config != want {
+		return fmt.Errorf("kuhn: snapshot is for %s, not %s", config, want)
+	}
+	nodeMap := make(map[string]*kuhnNode, len(s.Nodes))
+	for _, n := range s.Nodes {
+		node := newKuhnNode(0)
+		if err := node.RestoreNode(n); err != nil {
+			return err
+		}
+		nodeMap[n.InfoSet] = node
+	}
+	k.NodeMap = nodeMap
+	k.nodes = nil
+	k.iterations = s.Iterations
+	if s.Version >= 2 {
+		k.source = rng.Restore(s.Seed, s.RNGDraws)
+		k.rng = rand.New(k.source)
+	}
+	return nil
+}
+
+// snapshotString describes what a trainer's nodes depend on: the ranks in
+// the deck, the stakes and the number of players. The starting stack only
+// matters to a Game, so it is left out.
+func (c Config) snapshotString() string {
+	deck := append([]rune(nil), c.Deck...)
+	sort.Slice(deck, func(i, j int) bool { return GetCardRank(deck[i]) < GetCardRank(deck[j]) })
+	return fmt.Sprintf("deck %s ante %d bet %d players %d", string(deck), c.Ante, c.BetSize, c.Players)
+}
+
+func (k *KuhnTrainer) Save(path string, f snapshot.Format) error {
+	return snapshot.Save(path, k.Snapshot(), f)
+}
+
+func (k *KuhnTrainer) Load(path string) error {
+	s, err := snapshot.Load(path)
+	if err != nil {
+		return err
+	}
+	return k.Restore(s)
+}
@@ -0,0 +1,63 @@
+package kuhn
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/snapshot"
+)
+
+func TestSaveLoad(t *testing.T) {
+	trainer := NewKuhnTrainer(WithSeed(1))
+	trainer.TrainOutcomeSampling(10000)
+
+	for _, f := range []snapshot.Format{snapshot.JSON, snapshot.Binary} {
+		path := filepath.Join(t.TempDir(), "kuhn")
+		if err := trainer.Save(path, f); err != nil {
+			t.Fatal(err)
+		}
+		loaded := NewKuhnTrainer()
+		if err := loaded.Load(path); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded.Snapshot(), trainer.Snapshot()) {
+			t.Errorf("format %d: loaded trainer differs from the saved one", f)
+		}
+		for infoSet, node := range trainer.NodeMap {
+			if !reflect.DeepEqual(loaded.NodeMap[infoSet].GetAvgStrategy(), node.GetAvgStrategy()) {
+				t.Errorf("format %d: %s plays differently after loading", f, infoSet)
+			}
+		}
+	}
+}
+
+func TestLoadRejectsAnotherConfig(t *testing.T) {
+	classic := newTrainer(t, ClassicConfig(), WithSeed(1))
+	classic.Train(100)
+	path := filepath.Join(t.TempDir(), "kuhn")
+	if err := classic.Save(path, snapshot.JSON); err != nil {
+		t.Fatal(err)
+	}
+	full := NewKuhnTrainer()
+	if err := full.Load(path); err == nil {
+		t.Error("loaded a J, Q, K snapshot into the 13 card trainer")
+	}
+	classic = newTrainer(t, ClassicConfig())
+	if err := classic.Load(path); err != nil {
+		t.Errorf("a J, Q, K trainer could not load its own snapshot: %v", err)
+	}
+}
+
+func TestMissingNodeIsAnError(t *testing.T) {
+	ai := newTrainer(t, ClassicConfig(), WithSeed(1))
+	ai.Train(100)
+	delete(ai.NodeMap, "1 Kp")
+	delete(ai.NodeMap, "1 Kb")
+	g := newGame(t, ai, WithGameSeed(1), WithMaxHands(1))
+	g.BeginRound()
+	g.PlayerCard, g.AiCard = 'Q', 'K'
+	if err := g.Check(); err == nil {
+		t.Error("the AI acted without a strategy")
+	}
+}
@@ -0,0 +1,214 @@
+package kuhn
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/game"
+)
+
+var ruleConfigs = []Config{
+	ClassicConfig(),
+	DefaultConfig(),
+	{Deck: []rune{'2', '7', 'A'}, Ante: 2, BetSize: 3, Stack: 20, Players: 2},
+}
+
+// recordHistory is the betting history of a finished hand in the trainer's
+// notation.
+func recordHistory(h HandRecord) string {
+	var b strings.Builder
+	for _, a := range h.Actions {
+		if a.Action == "bet" || a.Action == "call" {
+			b.WriteByte('b')
+		} else {
+			b.WriteByte('p')
+		}
+	}
+	return b.String()
+}
+
+// checkHand checks a hand the web game finished against the trainer's rules:
+// the hand ends at the first history the trainer calls terminal, the chips
+// change hands as its payoff says, the winner is whoever gains, and every
+// payoff function agrees and sums to zero.
+func checkHand(t *testing.T, c Config, h HandRecord) {
+	t.Helper()
+	history := recordHistory(h)
+	cards := make([]rune, 2)
+	cards[h.PlayerPosition] = []rune(h.PlayerCard)[0]
+	cards[h.AiPosition] = []rune(h.AiCard)[0]
+	name := string(cards) + " " + history
+
+	for plays := 0; plays < len(history); plays++ {
+		if _, ok := c.terminalStatePayoff(cards, plays, history, plays%2, 1-plays%2); ok {
+			t.Errorf("%s: the trainer ends the hand after %q but the game played on", name, history[:plays])
+			return
+		}
+	}
+	toAct := len(history) % 2
+	payoff, ok := c.terminalStatePayoff(cards, len(history), history, toAct, 1-toAct)
+	if !ok {
+		t.Errorf("%s: the game ended a hand the trainer plays on", name)
+		return
+	}
+	want := payoff
+	if toAct != h.PlayerPosition {
+		want = -payoff
+	}
+
+	if h.PlayerDelta != want || h.AiDelta != -want {
+		t.Errorf("%s: deltas %d and %d, the trainer pays the player %d", name, h.PlayerDelta, h.AiDelta, want)
+	}
+	winner := PlayerActor
+	if want < 0 {
+		winner = AiActor
+	}
+	if h.Winner != winner {
+		t.Errorf("%s: %s won a hand worth %d to the player", name, h.Winner, want)
+	}
+	if showdown := history == "pp" || strings.HasSuffix(history, "bb"); h.Showdown != showdown {
+		t.Errorf("%s: showdown %v, want %v", name, h.Showdown, showdown)
+	}
+
+	payoffs, ok := c.payoffs(cards, ParseHistory(history), 2)
+	if !ok || payoffs[h.PlayerPosition] != float64(want) || payoffs[0]+payoffs[1] != 0 {
+		t.Errorf("%s: general payoffs %v, want %d for the player and zero-sum", name, payoffs, want)
+	}
+	state := kuhnGameState(c, cards, history)
+	if !state.IsTerminal() || state.Utility(h.PlayerPosition) != float64(want) || state.Utility(0)+state.Utility(1) != 0 {
+		t.Errorf("%s: KuhnGame utilities %v and %v, want %d for the player and zero-sum",
+			name, state.Utility(0), state.Utility(1), want)
+	}
+}
+
+// kuhnGameState deals cards in KuhnGame and plays history, checking that
+// the game.Game port does not end the hand early.
+func kuhnGameState(c Config, cards []rune, history string) game.State {
+	s := KuhnGame{Config: c}.Root()
+	for _, card := range cards {
+		s = s.Apply(strings.IndexRune(string(c.Deck), card))
+	}
+	for i := 0; i < len(history) && !s.IsTerminal(); i++ {
+		if history[i] == 'b' {
+			s = s.Apply(int(Bet))
+		} else {
+			s = s.Apply(int(Pass))
+		}
+	}
+	return s
+}
+
+// playHand plays a one-hand match in which the player holds playerCard and
+// the AI aiCard, and whoever is to act takes the next of actions.
+func playHand(t *testing.T, c Config, playerFirst bool, playerCard, aiCard rune, actions []Action) *Game {
+	t.Helper()
+	g := newGame(t, newTrainer(t, c), WithGameSeed(1), WithMaxHands(1))
+	if !playerFirst {
+		g.PlayerPosition, g.AiPosition = second, first
+	}
+	next := func() Action {
+		plays := len(g.ActionHistory)
+		if plays >= len(actions) {
+			t.Fatalf("the hand went on past %q", g.ActionHistory)
+		}
+		return actions[plays]
+	}
+	g.aiPolicy = func(string) Action { return next() }
+	g.BeginRound()
+	// the deal only matters at the end of the hand, so it can be replaced
+	// after an AI in first position has acted
+	g.PlayerCard, g.AiCard = playerCard, aiCard
+	g.CurrentHand.PlayerCard, g.CurrentHand.AiCard = string(playerCard), string(aiCard)
+
+	for g.GameState != MatchOver {
+		var err error
+		if next() == Bet {
+			err = g.Bet()
+		} else {
+			err = g.Check()
+		}
+		if err != nil {
+			t.Fatalf("after %q: %v", g.ActionHistory, err)
+		}
+	}
+	return g
+}
+
+// TestGameMatchesTrainerRules plays every deal, seat and sequence of actions
+// through the web game and compares each hand with the trainer's payoffs.
+// Every hand is over within three actions, so the sequences of three cover
+// every history.
+func TestGameMatchesTrainerRules(t *testing.T) {
+	var sequences [][]Action
+	for bits := 0; bits < 8; bits++ {
+		sequences = append(sequences, []Action{Action(bits >> 2 & 1), Action(bits >> 1 & 1), Action(bits & 1)})
+	}
+	for _, c := range ruleConfigs {
+		for _, playerCard := range c.Deck {
+			for _, aiCard := range c.Deck {
+				if playerCard == aiCard {
+					continue
+				}
+				for _, playerFirst := range []bool{true, false} {
+					for _, actions := range sequences {
+						g := playHand(t, c, playerFirst, playerCard, aiCard, actions)
+						if len(g.History) != 1 {
+							t.Fatalf("played %d hands, want 1", len(g.History))
+						}
+						h := g.History[0]
+						checkHand(t, c, h)
+						if g.PlayerStack != c.Stack+h.PlayerDelta || g.AiStack != c.Stack+h.AiDelta {
+							t.Errorf("%c%c %s: stacks %d and %d, deltas %d and %d", playerCard, aiCard,
+								recordHistory(h), g.PlayerStack, g.AiStack, h.PlayerDelta, h.AiDelta)
+						}
+						if err := g.Check(); !errors.Is(err, ErrGameOver) {
+							t.Errorf("%c%c %s: acting after the hand returned %v", playerCard, aiCard, recordHistory(h), err)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// FuzzMatch plays seeded matches with the player's and the AI's actions taken
+// from moves, checking every finished hand against the trainer's rules and
+// that no chips are made or lost.
+func FuzzMatch(f *testing.F) {
+	f.Add(int64(1), uint8(0), []byte{0, 1, 1, 0, 1, 0, 0, 0, 1, 1, 1, 0})
+	f.Add(int64(2), uint8(1), []byte{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+	f.Add(int64(3), uint8(2), []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	f.Fuzz(func(t *testing.T, seed int64, config uint8, moves []byte) {
+		c := ruleConfigs[int(config)%len(ruleConfigs)]
+		g := newGame(t, newTrainer(t, c), WithGameSeed(seed))
+		move := func() Action {
+			if len(moves) == 0 {
+				return Pass
+			}
+			a := Action(moves[0] & 1)
+			moves = moves[1:]
+			return a
+		}
+		g.aiPolicy = func(string) Action { return move() }
+		g.BeginRound()
+
+		for hands := 0; g.GameState != MatchOver && len(moves) > 0; {
+			var err error
+			if move() == Bet {
+				err = g.Bet()
+			} else {
+				err = g.Check()
+			}
+			if err != nil {
+				t.Fatalf("after %q: %v", g.ActionHistory, err)
+			}
+			if g.PlayerStack+g.AiStack+g.Pot != 2*c.Stack {
+				t.Fatalf("stacks %d and %d and pot %d, want %d chips in all", g.PlayerStack, g.AiStack, g.Pot, 2*c.Stack)
+			}
+			for ; hands < len(g.History); hands++ {
+				checkHand(t, c, g.History[hands])
+			}
+		}
+	})
+}
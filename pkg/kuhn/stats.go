@@ -0,0 +1,126 @@
+package kuhn
+
+import (
+	"math"
+	"sort"
+)
+
+// Stats accumulates the player's results against the AI, one finished hand
+// at a time, so a server can keep them for every hand it has seen without
+// keeping the hands.
+type Stats struct {
+	Hands int
+	// Winnings are the player's net chips; the AI's are the negation.
+	Winnings            int
+	ShowdownWinnings    int
+	NonShowdownWinnings int
+	Showdowns           int
+	// SquaredWinnings sums each hand's winnings squared, for the variance.
+	SquaredWinnings int
+	Actions         []ActionFrequency
+}
+
+// ActionFrequency counts the actions Actor took holding Card from Position.
+type ActionFrequency struct {
+	Actor    string         `json:"actor"`
+	Card     string         `json:"card"`
+	Position int            `json:"position"`
+	Counts   map[string]int `json:"counts"`
+}
+
+// z95 is the normal quantile for a two-sided 95% confidence interval.
+const z95 = 1.96
+
+func StatsOf(hands []HandRecord) Stats {
+	var s Stats
+	for _, h := range hands {
+		s.Add(h)
+	}
+	return s
+}
+
+func (g *Game) Stats() Stats {
+	return StatsOf(g.History)
+}
+
+func (s *Stats) Add(h HandRecord) {
+	s.Hands++
+	s.Winnings += h.PlayerDelta
+	s.SquaredWinnings += h.PlayerDelta * h.PlayerDelta
+	if h.Showdown {
+		s.Showdowns++
+		s.ShowdownWinnings += h.PlayerDelta
+	} else {
+		s.NonShowdownWinnings += h.PlayerDelta
+	}
+	for _, a := range h.Actions {
+		card, position := h.PlayerCard, h.PlayerPosition
+		if a.Actor == AiActor {
+			card, position = h.AiCard, h.AiPosition
+		}
+		s.frequency(a.Actor, card, position).Counts[a.Action]++
+	}
+}
+
+// frequency returns the counts for actor holding card from position, adding
+// them in actor, position and card order if they are new.
+func (s *Stats) frequency(actor, card string, position int) *ActionFrequency {
+	less := func(f ActionFrequency) bool {
+		if f.Actor != actor {
+			return f.Actor > actor
+		}
+		if f.Position != position {
+			return f.Position < position
+		}
+		return GetCardRank([]rune(f.Card)[0]) < GetCardRank([]rune(card)[0])
+	}
+	i := sort.Search(len(s.Actions), func(i int) bool { return !less(s.Actions[i]) })
+	if i == len(s.Actions) || s.Actions[i].Actor != actor || s.Actions[i].Card != card || s.Actions[i].Position != position {
+		s.Actions = append(s.Actions, ActionFrequency{})
+		copy(s.Actions[i+1:], s.Actions[i:])
+		s.Actions[i] = ActionFrequency{Actor: actor, Card: card, Position: position, Counts: make(map[string]int)}
+	}
+	return &s.Actions[i]
+}
+
+// Frequency is the share of f's actions that were action.
+func (f ActionFrequency) Frequency(action string) float64 {
+	total := 0
+	for _, n := range f.Counts {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(f.Counts[action]) / float64(total)
+}
+
+// WinRate is the player's winnings in chips per 100 hands. With the default
+// ante of one chip this is poker's bb/100 with the ante as the big blind.
+func (s Stats) WinRate() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return 100 * float64(s.Winnings) / float64(s.Hands)
+}
+
+// StdDev is the sample standard deviation of the player's winnings per hand.
+func (s Stats) StdDev() float64 {
+	if s.Hands < 2 {
+		return 0
+	}
+	n := float64(s.Hands)
+	mean := float64(s.Winnings) / n
+	variance := (float64(s.SquaredWinnings) - n*mean*mean) / (n - 1)
+	return math.Sqrt(math.Max(variance, 0))
+}
+
+// ConfidenceInterval is the 95% confidence interval of WinRate. When it
+// contains zero the player cannot yet be told apart from running hot or cold.
+func (s Stats) ConfidenceInterval() (low, high float64) {
+	if s.Hands < 2 {
+		return math.Inf(-1), math.Inf(1)
+	}
+	margin := 100 * z95 * s.StdDev() / math.Sqrt(float64(s.Hands))
+	return s.WinRate() - margin, s.WinRate() + margin
+}
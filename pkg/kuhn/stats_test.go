@@ -0,0 +1,67 @@
+package kuhn
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	hands := []HandRecord{
+		// The player bets a K first and is called.
+		{PlayerPosition: 0, AiPosition: 1, PlayerCard: "K", AiCard: "Q", Showdown: true, PlayerDelta: 2, AiDelta: -2,
+			Actions: []ActionRecord{{PlayerActor, "bet", 1}, {AiActor, "call", 1}}},
+		// The AI bets first and the player folds a 2.
+		{PlayerPosition: 1, AiPosition: 0, PlayerCard: "2", AiCard: "A", PlayerDelta: -1, AiDelta: 1,
+			Actions: []ActionRecord{{AiActor, "bet", 1}, {PlayerActor, "fold", 0}}},
+		// Both check a K first and the player loses to an A.
+		{PlayerPosition: 0, AiPosition: 1, PlayerCard: "K", AiCard: "A", Showdown: true, PlayerDelta: -1, AiDelta: 1,
+			Actions: []ActionRecord{{PlayerActor, "check", 0}, {AiActor, "check", 0}}},
+	}
+	s := StatsOf(hands)
+
+	if s.Hands != 3 || s.Winnings != 0 || s.Showdowns != 2 || s.ShowdownWinnings != 1 || s.NonShowdownWinnings != -1 {
+		t.Errorf("stats %+v", s)
+	}
+	if s.WinRate() != 0 {
+		t.Errorf("win rate %.2f, want 0", s.WinRate())
+	}
+	// Winnings 2, -1, -1 have a sample standard deviation of sqrt(3).
+	if math.Abs(s.StdDev()-math.Sqrt(3)) > 1e-9 {
+		t.Errorf("standard deviation %.4f, want sqrt(3)", s.StdDev())
+	}
+	low, high := s.ConfidenceInterval()
+	if margin := 100 * 1.96; math.Abs(low+margin) > 1e-9 || math.Abs(high-margin) > 1e-9 {
+		t.Errorf("confidence interval [%.2f, %.2f], want ±%.2f", low, high, margin)
+	}
+
+	want := []struct {
+		actor    string
+		position int
+		card     string
+		counts   map[string]int
+	}{
+		{PlayerActor, 0, "K", map[string]int{"bet": 1, "check": 1}},
+		{PlayerActor, 1, "2", map[string]int{"fold": 1}},
+		{AiActor, 0, "A", map[string]int{"bet": 1}},
+		{AiActor, 1, "Q", map[string]int{"call": 1}},
+		{AiActor, 1, "A", map[string]int{"check": 1}},
+	}
+	if len(s.Actions) != len(want) {
+		t.Fatalf("%d action frequencies, want %d", len(s.Actions), len(want))
+	}
+	for i, w := range want {
+		f := s.Actions[i]
+		if f.Actor != w.actor || f.Position != w.position || f.Card != w.card || len(f.Counts) != len(w.counts) {
+			t.Errorf("frequency %d is %+v, want %s %d %s", i, f, w.actor, w.position, w.card)
+			continue
+		}
+		for action, n := range w.counts {
+			if f.Counts[action] != n {
+				t.Errorf("%s %s: %d %s, want %d", w.actor, w.card, f.Counts[action], action, n)
+			}
+		}
+	}
+	if got := s.Actions[0].Frequency("bet"); got != 0.5 {
+		t.Errorf("player bets K first with frequency %.2f, want 0.5", got)
+	}
+}
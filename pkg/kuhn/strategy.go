@@ -0,0 +1,59 @@
+package kuhn
+
+import (
+	"fmt"
+	"sort"
+)
+
+// InfoSetStrategy is one information set's average strategy, split into the
+// parts of its key.
+type InfoSetStrategy struct {
+	InfoSet string `json:"infoSet"`
+	Player  int    `json:"player"`
+	Card    string `json:"card"`
+	History string `json:"history"`
+	// Strategy is the probability of passing and of betting.
+	Strategy []float64 `json:"strategy"`
+}
+
+// Strategies returns every information set in NodeMap, ordered by player,
+// history and card rank.
+func (k *KuhnTrainer) Strategies() []InfoSetStrategy {
+	strategies := make([]InfoSetStrategy, 0, len(k.NodeMap))
+	for infoSet, node := range k.NodeMap {
+		var player int
+		var card rune
+		var history string
+		fmt.Sscanf(infoSet, "%d %c%s", &player, &card, &history)
+		strategies = append(strategies, InfoSetStrategy{
+			InfoSet:  infoSet,
+			Player:   player,
+			Card:     string(card),
+			History:  history,
+			Strategy: node.GetAvgStrategy(),
+		})
+	}
+	sort.Slice(strategies, func(i, j int) bool {
+		a, b := strategies[i], strategies[j]
+		if a.Player != b.Player {
+			return a.Player < b.Player
+		}
+		if a.History != b.History {
+			return len(a.History) < len(b.History) || len(a.History) == len(b.History) && a.History > b.History
+		}
+		return GetCardRank([]rune(a.Card)[0]) > GetCardRank([]rune(b.Card)[0])
+	})
+	return strategies
+}
+
+// AiDecision is the position and history of the AI's latest decision this
+// hand, such as "1 p", or "" if it has not acted yet. It leaves out the AI's
+// card, so it names the column of information sets the AI could be in.
+func (g *Game) AiDecision() string {
+	for i := len(g.CurrentHand.Actions) - 1; i >= 0; i-- {
+		if g.CurrentHand.Actions[i].Actor == AiActor {
+			return fmt.Sprintf("%d %s", g.AiPosition, g.ActionHistory[:i])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package kuhn
+
+import "testing"
+
+func TestStrategies(t *testing.T) {
+	k := NewKuhnTrainer(WithSeed(1))
+	k.Train(10000)
+	strategies := k.Strategies()
+	if len(strategies) != len(k.NodeMap) {
+		t.Fatalf("%d strategies for %d information sets", len(strategies), len(k.NodeMap))
+	}
+	if first := strategies[0]; first.InfoSet != "0 A" || first.Player != 0 || first.Card != "A" || first.History != "" {
+		t.Errorf("first strategy %+v, want player 1 opening with an A", first)
+	}
+	if last := strategies[len(strategies)-1]; last.InfoSet != "1 2b" || last.History != "b" {
+		t.Errorf("last strategy %+v, want player 2 facing a bet with a 2", last)
+	}
+	for _, s := range strategies {
+		if got := s.Strategy; got[0]+got[1] < 0.99 || got[0]+got[1] > 1.01 {
+			t.Errorf("%s: strategy %v does not sum to 1", s.InfoSet, got)
+		}
+	}
+}
+
+func TestAiDecision(t *testing.T) {
+	ai := NewKuhnTrainer(WithSeed(1))
+	ai.Train(10000)
+	g := newGame(t, ai, WithGameSeed(2))
+	g.BeginRound()
+	// The player opens the first hand, so the AI has not acted yet.
+	if got := g.AiDecision(); got != "" {
+		t.Errorf("before the AI acts: %q", got)
+	}
+	g.Bet()
+	// The AI called or folded, and the second hand opened with its action.
+	if got := g.AiDecision(); got != "0 " {
+		t.Errorf("after the AI opens: %q", got)
+	}
+}
@@ -0,0 +1,113 @@
+package kuhn
+
+import "math"
+
+type Variant int
+
+const (
+	Vanilla Variant = iota
+	// CFRPlus floors cumulative regret at zero, alternates which player is
+	// updated each iteration and weights the average strategy linearly by
+	// iteration.
+	CFRPlus
+	// Discounted is Brown & Sandholm's DCFR: after iteration t, positive
+	// regrets are scaled by t^alpha/(t^alpha+1), negative regrets by
+	// t^beta/(t^beta+1) and the average strategy by (t/(t+1))^gamma.
+	Discounted
+)
+
+// Every variant other than Vanilla sweeps all deals each iteration instead of
+// shuffling one, since flooring and discounting regrets throw away the
+// information that sampling noise would otherwise average out.
+
+// allPlayers is passed to cfr as the traverser when every player's regrets
+// are updated in the same pass.
+const allPlayers = -1
+
+func WithVariant(v Variant) Option {
+	return func(k *KuhnTrainer) {
+		k.variant = v
+	}
+}
+
+// WithDiscount selects the Discounted variant with the given exponents.
+func WithDiscount(alpha, beta, gamma float64) Option {
+	return func(k *KuhnTrainer) {
+		k.variant = Discounted
+		k.alpha, k.beta, k.gamma = alpha, beta, gamma
+	}
+}
+
+// WithDCFR uses the parameters Brown & Sandholm recommend.
+func WithDCFR() Option {
+	return WithDiscount(1.5, 0, 2)
+}
+
+// WithLinearCFR weights iteration t's regrets and strategy by t.
+func WithLinearCFR() Option {
+	return WithDiscount(1, 1, 1)
+}
+
+// sweepIteration updates each player in turn over every deal. Strategies are
+// fixed for the whole pass and regrets are floored or discounted once the
+// iteration has summed them. It returns player 1's expected value.
+func (k *KuhnTrainer) sweepIteration() float64 {
+	cards := make([]rune, 2)
+	deals := float64(len(k.config.Deck) * (len(k.config.Deck) - 1))
+	util := 0.0
+	for traverser := 0; traverser < 2; traverser++ {
+		for _, node := range k.NodeMap {
+			node.RegretMatching()
+		}
+		for i, c0 := range k.config.Deck {
+			for j, c1 := range k.config.Deck {
+				if i == j {
+					continue
+				}
+				cards[0], cards[1] = c0, c1
+				value := k.cfr(cards, EmptyHistory, 1, 1, traverser)
+				if traverser == 0 {
+					util += value / deals
+				}
+			}
+		}
+	}
+	k.discount()
+	return util
+}
+
+func (k *KuhnTrainer) discount() {
+	t := float64(k.iterations)
+	switch k.variant {
+	case CFRPlus:
+		for _, node := range k.NodeMap {
+			node.discount(1, 0, 1)
+		}
+	case Discounted:
+		positive := math.Pow(t, k.alpha) / (math.Pow(t, k.alpha) + 1)
+		negative := math.Pow(t, k.beta) / (math.Pow(t, k.beta) + 1)
+		strategy := math.Pow(t/(t+1), k.gamma)
+		for _, node := range k.NodeMap {
+			node.discount(positive, negative, strategy)
+		}
+	}
+}
+
+// strategyWeight scales this iteration's contribution to strategySum.
+func (k *KuhnTrainer) strategyWeight() float64 {
+	if k.variant == CFRPlus {
+		return float64(k.iterations)
+	}
+	return 1
+}
+
+func (n *kuhnNode) discount(positive, negative, strategy float64) {
+	for i := 0; i < n.NumActions(); i++ {
+		if n.RegretSum[i] > 0 {
+			n.RegretSum[i] *= positive
+		} else {
+			n.RegretSum[i] *= negative
+		}
+		n.StrategySum[i] *= strategy
+	}
+}
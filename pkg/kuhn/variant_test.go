@@ -0,0 +1,43 @@
+package kuhn
+
+import "testing"
+
+func TestCFRPlusConvergesFasterThanVanilla(t *testing.T) {
+	// A CFR+ iteration visits all 156 deals of the 13 card deck, so give
+	// vanilla the same number of deals.
+	const cfrPlusIterations = 200
+	deals := cfrPlusIterations * 13 * 12
+
+	vanilla := NewKuhnTrainer()
+	vanilla.Train(deals)
+	plus := NewKuhnTrainer(WithVariant(CFRPlus))
+	plus.Train(cfrPlusIterations)
+
+	t.Logf("exploitability after %d deals: vanilla %.3f, CFR+ %.3f milli-chips per hand",
+		deals, vanilla.Exploitability(), plus.Exploitability())
+	if plus.Exploitability() >= vanilla.Exploitability() {
+		t.Errorf("CFR+ exploitability %.3f is not below vanilla %.3f", plus.Exploitability(), vanilla.Exploitability())
+	}
+	if plus.Exploitability() > 5 {
+		t.Errorf("CFR+ exploitability = %.3f milli-chips, want under 5", plus.Exploitability())
+	}
+}
+
+func TestDiscountedVariantsConverge(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"DCFR", WithDCFR()},
+		{"LinearCFR", WithLinearCFR()},
+		{"Discount(2,0.5,1)", WithDiscount(2, 0.5, 1)},
+	}
+	for _, tc := range tests {
+		trainer := NewKuhnTrainer(tc.opt)
+		trainer.Train(200)
+		t.Logf("%s exploitability after 200 iterations: %.3f milli-chips per hand", tc.name, trainer.Exploitability())
+		if trainer.Exploitability() > 5 {
+			t.Errorf("%s exploitability = %.3f milli-chips, want under 5", tc.name, trainer.Exploitability())
+		}
+	}
+}
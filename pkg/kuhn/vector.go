@@ -0,0 +1,192 @@
+package kuhn
+
+import "sort"
+
+// WithVectorForm makes every iteration walk each betting history once,
+// carrying a reach probability and a value for every card a player could
+// hold, instead of walking the tree once per deal. An iteration makes the
+// same updates as the variant's sweep over every deal, so with Vanilla it is
+// full-chance CFR with alternating updates. It runs on one goroutine.
+func WithVectorForm() Option {
+	return func(k *KuhnTrainer) {
+		k.vector = true
+	}
+}
+
+// vectorFrame holds the buffers vectorCFR uses at one history. They are
+// allocated the first time the history is reached, so later iterations
+// allocate nothing. Each history is reached once per traversal, so the
+// values a child returns stay valid until its parent is done with them.
+type vectorFrame struct {
+	nodes  []*kuhnNode
+	values [2][]float64
+	// nextReach and children are the acting player's reach and both
+	// players' values after each action.
+	nextReach [][]float64
+	children  [][2][]float64
+}
+
+// frame returns history's buffers.
+func (k *KuhnTrainer) frame(history History) *vectorFrame {
+	if f, ok := k.frames[history]; ok {
+		return f
+	}
+	n := len(k.config.Deck)
+	f := &vectorFrame{
+		nodes:     make([]*kuhnNode, n),
+		values:    [2][]float64{make([]float64, n), make([]float64, n)},
+		nextReach: make([][]float64, k.numActions),
+		children:  make([][2][]float64, k.numActions),
+	}
+	for a := range f.nextReach {
+		f.nextReach[a] = make([]float64, n)
+	}
+	if k.frames == nil {
+		k.frames = make(map[History]*vectorFrame)
+	}
+	k.frames[history] = f
+	return f
+}
+
+// vectorIteration is sweepIteration in vector form. It returns player 1's
+// expected value.
+func (k *KuhnTrainer) vectorIteration() float64 {
+	n := len(k.config.Deck)
+	if k.byRank == nil {
+		k.byRank = make([]int, n)
+		for c := range k.byRank {
+			k.byRank[c] = c
+		}
+		sort.Slice(k.byRank, func(i, j int) bool {
+			return GetCardRank(k.config.Deck[k.byRank[i]]) < GetCardRank(k.config.Deck[k.byRank[j]])
+		})
+		for p := range k.rootReach {
+			k.rootReach[p] = make([]float64, n)
+			for c := range k.rootReach[p] {
+				k.rootReach[p][c] = 1
+			}
+		}
+	}
+
+	util := 0.0
+	for traverser := 0; traverser < 2; traverser++ {
+		for _, node := range k.NodeMap {
+			node.RegretMatching()
+		}
+		values := k.vectorCFR(EmptyHistory, k.rootReach, traverser)
+		if traverser == 0 {
+			for _, v := range values[0] {
+				util += v / float64(n*(n-1))
+			}
+		}
+	}
+	k.discount()
+	return util
+}
+
+// vectorCFR returns each player's counterfactual value of history for every
+// card they could hold: what they win summed over the other player's cards,
+// weighted by the other player's reach.
+func (k *KuhnTrainer) vectorCFR(history History, reach [2][]float64, traverser int) [2][]float64 {
+	f := k.frame(history)
+	if k.terminalValues(history, reach, f.values) {
+		return f.values
+	}
+	player := history.Len() % 2
+	opponent := 1 - player
+	for c, card := range k.config.Deck {
+		f.nodes[c] = k.node(player, card, history)
+	}
+
+	values := f.values
+	for p := range values {
+		for c := range values[p] {
+			values[p][c] = 0
+		}
+	}
+	for a := 0; a < k.numActions; a++ {
+		var nextReach [2][]float64
+		nextReach[opponent] = reach[opponent]
+		nextReach[player] = f.nextReach[a]
+		for c, node := range f.nodes {
+			nextReach[player][c] = reach[player][c] * node.Strategy[a]
+		}
+		f.children[a] = k.vectorCFR(history.Append(a), nextReach, traverser)
+		for c, node := range f.nodes {
+			values[player][c] += node.Strategy[a] * f.children[a][player][c]
+			values[opponent][c] += f.children[a][opponent][c]
+		}
+	}
+
+	if player != traverser {
+		return values
+	}
+	// a sweep adds a card's strategy once for each card the other player
+	// can hold
+	deals := float64(len(f.nodes) - 1)
+	for c, node := range f.nodes {
+		for a := 0; a < node.NumActions(); a++ {
+			node.RegretSum[a] += f.children[a][player][c] - values[player][c]
+		}
+		node.AccumulateStrategy(reach[player][c] * deals * k.strategyWeight())
+	}
+	return values
+}
+
+// terminalValues fills values with both players' values of a finished
+// history for every card, and returns false while the hand is not over. Each
+// takes a pass over the cards in rank order rather than one per pair of
+// cards.
+func (k *KuhnTrainer) terminalValues(history History, reach [2][]float64, values [2][]float64) bool {
+	plays := history.Len()
+	if plays < 2 {
+		return false
+	}
+	var stake int
+	switch {
+	case history == passPass:
+		stake = k.config.Ante
+	case history&3 == betBet:
+		stake = k.config.Ante + k.config.BetSize
+	case history&1 == History(Pass):
+		k.foldValues(plays%2, reach, values)
+		return true
+	default:
+		return false
+	}
+
+	for p := range values {
+		opponent := reach[1-p]
+		total := 0.0
+		for _, r := range opponent {
+			total += r
+		}
+		// every card below c loses to it and every card above beats it
+		below := 0.0
+		for _, c := range k.byRank {
+			above := total - below - opponent[c]
+			values[p][c] = float64(stake) * (below - above)
+			below += opponent[c]
+		}
+	}
+	return true
+}
+
+// foldValues is terminalValues for a hand the other player folded, leaving
+// winner the ante.
+func (k *KuhnTrainer) foldValues(winner int, reach [2][]float64, values [2][]float64) {
+	for p := range values {
+		opponent := reach[1-p]
+		total := 0.0
+		for _, r := range opponent {
+			total += r
+		}
+		sign := 1.0
+		if p != winner {
+			sign = -1
+		}
+		for c := range values[p] {
+			values[p][c] = sign * float64(k.config.Ante) * (total - opponent[c])
+		}
+	}
+}
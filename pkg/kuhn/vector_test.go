@@ -0,0 +1,52 @@
+package kuhn
+
+import (
+	"math"
+	"testing"
+)
+
+// Vector form makes the same updates as sweeping every deal, so the nodes
+// must agree up to rounding.
+func TestVectorFormMatchesSweep(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"CFR+", WithVariant(CFRPlus)},
+		{"DCFR", WithDCFR()},
+		{"linear", WithLinearCFR()},
+	}
+	for _, tc := range tests {
+		sweep := NewKuhnTrainer(WithSeed(1), tc.opt)
+		sweep.Train(200)
+		vector := NewKuhnTrainer(WithSeed(1), tc.opt, WithVectorForm())
+		vector.Train(200)
+
+		if len(vector.NodeMap) != len(sweep.NodeMap) {
+			t.Fatalf("%s: %d nodes, sweep has %d", tc.name, len(vector.NodeMap), len(sweep.NodeMap))
+		}
+		for infoSet, want := range sweep.NodeMap {
+			got := vector.NodeMap[infoSet]
+			for a := range want.RegretSum {
+				if !near(got.RegretSum[a], want.RegretSum[a]) || !near(got.StrategySum[a], want.StrategySum[a]) {
+					t.Errorf("%s %s: sums %v %v, sweep %v %v", tc.name, infoSet, got.RegretSum, got.StrategySum, want.RegretSum, want.StrategySum)
+				}
+			}
+		}
+	}
+}
+
+func TestVectorFormConverges(t *testing.T) {
+	k := newTrainer(t, ClassicConfig(), WithVectorForm())
+	k.Train(1000)
+	if e := k.Exploitability(); e > 5 {
+		t.Errorf("exploitability %.3f milli-chips after 1000 iterations, want at most 5", e)
+	}
+	if v := k.Value(); math.Abs(v+1.0/18) > 0.005 {
+		t.Errorf("value %.4f, want -1/18", v)
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
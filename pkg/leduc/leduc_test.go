@@ -0,0 +1,114 @@
+package leduc
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/game"
+)
+
+// Leduc Hold'em is worth -0.0856 per hand to the first player.
+const leducValue = -0.0856
+
+func TestLeducValue(t *testing.T) {
+	trainer := NewLeducTrainer()
+	trainer.Train(2000)
+
+	value := trainer.Value()
+	if math.Abs(value[0]-leducValue) > 0.005 {
+		t.Errorf("player 1 value = %.4f, want %.4f", value[0], leducValue)
+	}
+	if len(trainer.NodeMap) != 288 {
+		t.Errorf("%d information sets, want 288", len(trainer.NodeMap))
+	}
+}
+
+// play deals cards as player 0's, player 1's and the public card, in the
+// order chance deals them, and plays the betting.
+func play(t *testing.T, cards []int, betting string) game.State {
+	t.Helper()
+	s := NewLeducGame().Root()
+	actions := map[rune]int{'f': Fold, 'c': Call, 'r': Raise}
+	for _, a := range betting {
+		for s.CurrentPlayer() == game.Chance {
+			s = s.Apply(cards[0])
+			cards = cards[1:]
+		}
+		if a == '/' {
+			continue
+		}
+		if s.IsTerminal() {
+			t.Fatalf("hand is over before %c", a)
+		}
+		s = s.Apply(actions[a])
+	}
+	for len(cards) > 0 && !s.IsTerminal() && s.CurrentPlayer() == game.Chance {
+		s = s.Apply(cards[0])
+		cards = cards[1:]
+	}
+	return s
+}
+
+func TestLeducPayoffs(t *testing.T) {
+	const j, q, k = 0, 1, 2
+	tests := []struct {
+		name    string
+		cards   []int
+		betting string
+		// value is player 0's winnings, or NaN when the hand is not over.
+		value float64
+	}{
+		{"fold to a bet", []int{k, j}, "rf", 1},
+		{"fold to a raise", []int{j, k}, "rrf", -3},
+		{"checked down, high card", []int{k, q, j}, "cc/cc", 1},
+		{"checked down, pair", []int{j, k, j}, "cc/cc", 1},
+		{"tie", []int{q, q, k}, "rc/rc", 0},
+		{"second round bets 4", []int{q, k, j}, "rc/rc", -7},
+		{"capped both rounds", []int{k, q, k}, "crrc/rrc", 13},
+		{"fold in the second round", []int{j, q, k}, "cc/crf", -1},
+		{"checked round one", []int{j, q}, "cc", math.NaN()},
+	}
+	for _, tc := range tests {
+		s := play(t, tc.cards, tc.betting)
+		if math.IsNaN(tc.value) {
+			if s.IsTerminal() {
+				t.Errorf("%s: hand is over", tc.name)
+			}
+			continue
+		}
+		if !s.IsTerminal() {
+			t.Errorf("%s: hand is not over", tc.name)
+			continue
+		}
+		if got := s.Utility(0); got != tc.value || s.Utility(1) != -got {
+			t.Errorf("%s: utilities %v and %v, want %v", tc.name, got, s.Utility(1), tc.value)
+		}
+	}
+}
+
+func TestLeducActions(t *testing.T) {
+	tests := []struct {
+		betting string
+		key     string
+		want    []int
+	}{
+		{"", "K|", []int{Call, Raise}},
+		{"r", "Q|r", []int{Fold, Call, Raise}},
+		{"rr", "K|rr", []int{Fold, Call}},
+		{"cc/", "KJ|cc/", []int{Call, Raise}},
+		{"rc/cr", "KJ|rc/cr", []int{Fold, Call, Raise}},
+	}
+	for _, tc := range tests {
+		s := play(t, []int{2, 1, 0}, tc.betting)
+		if got := s.LegalActions(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: legal actions %v, want %v", tc.betting, got, tc.want)
+		}
+		if got := s.InfoSetKey(); got != tc.key {
+			t.Errorf("%q: key %q, want %q", tc.betting, got, tc.key)
+		}
+		if names := ActionNames(s.InfoSetKey()); len(names) != len(tc.want) {
+			t.Errorf("%q: action names %v for %v", tc.betting, names, tc.want)
+		}
+	}
+}
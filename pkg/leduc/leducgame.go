@@ -0,0 +1,201 @@
+// Package leduc is Leduc Hold'em: two players, a deck of two jacks, two
+// queens and two kings, one private card each and a public card dealt between
+// two betting rounds.
+package leduc
+
+import (
+	"strings"
+
+	"github.com/pepperonirollz/cfr/pkg/game"
+)
+
+const (
+	Fold = iota
+	Call
+	Raise
+)
+
+const (
+	ante = 1
+	// maxRaises caps the bets and raises in a round, so a round is at most
+	// bet, raise, call.
+	maxRaises = 2
+	// copies of each rank in the deck
+	copies = 2
+)
+
+// ranks are the cards from lowest to highest. Chance deals the index of a
+// rank, since the two cards of a rank are interchangeable.
+var ranks = []rune{'J', 'Q', 'K'}
+
+// raiseSize is the fixed bet of each round.
+var raiseSize = [2]int{2, 4}
+
+// LeducGame is Leduc Hold'em as a game.Game. Information set keys are the
+// player's card, the public card once it is dealt, a '|' and the betting so
+// far with a '/' between the rounds, such as "KQ|rc/r".
+type LeducGame struct{}
+
+type leducState struct {
+	// cards are the ranks dealt so far: player 0's, player 1's and the
+	// public card.
+	cards []int
+	// history is the betting of the finished rounds, each followed by '/',
+	// and betting holds the round in progress.
+	history string
+	betting string
+	round   int
+	raises  int
+	// committed is what each player has put in the pot.
+	committed [2]int
+	// folder is the player who folded, or -1.
+	folder   int
+	showdown bool
+}
+
+func NewLeducGame() LeducGame {
+	return LeducGame{}
+}
+
+func (g LeducGame) NumPlayers() int {
+	return 2
+}
+
+func (g LeducGame) Root() game.State {
+	return leducState{committed: [2]int{ante, ante}, folder: -1}
+}
+
+func (s leducState) IsTerminal() bool {
+	return s.folder >= 0 || s.showdown
+}
+
+func (s leducState) CurrentPlayer() int {
+	if len(s.cards) < 2 || s.round == 1 && len(s.cards) < 3 {
+		return game.Chance
+	}
+	return len(s.betting) % 2
+}
+
+func (s leducState) LegalActions() []int {
+	actions := make([]int, 0, 3)
+	if s.committed[0] != s.committed[1] {
+		actions = append(actions, Fold)
+	}
+	actions = append(actions, Call)
+	if s.raises < maxRaises {
+		actions = append(actions, Raise)
+	}
+	return actions
+}
+
+func (s leducState) ChanceOutcomes() []game.Outcome {
+	remaining := len(ranks)*copies - len(s.cards)
+	var outcomes []game.Outcome
+	for rank := range ranks {
+		left := copies
+		for _, c := range s.cards {
+			if c == rank {
+				left--
+			}
+		}
+		if left > 0 {
+			outcomes = append(outcomes, game.Outcome{Action: rank, Prob: float64(left) / float64(remaining)})
+		}
+	}
+	return outcomes
+}
+
+func (s leducState) Apply(action int) game.State {
+	next := s
+	if s.CurrentPlayer() == game.Chance {
+		next.cards = append(append([]int(nil), s.cards...), action)
+		return next
+	}
+	player := s.CurrentPlayer()
+	opponent := 1 - player
+	switch action {
+	case Fold:
+		next.betting += "f"
+		next.folder = player
+	case Call:
+		next.betting += "c"
+		next.committed[player] = s.committed[opponent]
+		// a check opening the round leaves the other player to act
+		if s.betting != "" {
+			next.endRound()
+		}
+	case Raise:
+		next.betting += "r"
+		next.committed[player] = s.committed[opponent] + raiseSize[s.round]
+		next.raises++
+	}
+	return next
+}
+
+func (s *leducState) endRound() {
+	if s.round == 1 {
+		s.showdown = true
+		return
+	}
+	s.history += s.betting + "/"
+	s.betting = ""
+	s.round++
+	s.raises = 0
+}
+
+func (s leducState) Utility(player int) float64 {
+	opponent := 1 - player
+	if s.folder == player {
+		return -float64(s.committed[player])
+	}
+	if s.folder == opponent {
+		return float64(s.committed[opponent])
+	}
+	switch mine, theirs := s.strength(player), s.strength(opponent); {
+	case mine > theirs:
+		return float64(s.committed[opponent])
+	case mine < theirs:
+		return -float64(s.committed[player])
+	}
+	return 0
+}
+
+// strength ranks player's hand at showdown: any pair with the public card
+// beats every unpaired card.
+func (s leducState) strength(player int) int {
+	if s.cards[player] == s.cards[2] {
+		return len(ranks) + s.cards[player]
+	}
+	return s.cards[player]
+}
+
+func (s leducState) InfoSetKey() string {
+	player := s.CurrentPlayer()
+	var b strings.Builder
+	b.WriteRune(ranks[s.cards[player]])
+	if len(s.cards) == 3 {
+		b.WriteRune(ranks[s.cards[2]])
+	}
+	b.WriteByte('|')
+	b.WriteString(s.history)
+	b.WriteString(s.betting)
+	return b.String()
+}
+
+// ActionNames names the actions of the information set key's strategy, in
+// order.
+func ActionNames(infoSet string) []string {
+	betting := infoSet[strings.LastIndexByte(infoSet, '/')+1:]
+	if i := strings.IndexByte(betting, '|'); i >= 0 {
+		betting = betting[i+1:]
+	}
+	raises := strings.Count(betting, "r")
+	names := []string{"check", "bet"}
+	if raises > 0 {
+		names = []string{"fold", "call", "raise"}
+	}
+	if raises == maxRaises {
+		names = names[:len(names)-1]
+	}
+	return names
+}
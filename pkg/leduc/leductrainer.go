@@ -0,0 +1,42 @@
+package leduc
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+)
+
+// LeducTrainer runs vanilla CFR on LeducGame with the generic engine in
+// pkg/cfr.
+type LeducTrainer struct {
+	*cfr.Trainer
+}
+
+func NewLeducTrainer(opts ...cfr.Option) LeducTrainer {
+	return LeducTrainer{cfr.NewTrainer(NewLeducGame(), opts...)}
+}
+
+// Report writes the first player's value, every information set's average
+// strategy and the number of information sets to w.
+func (t LeducTrainer) Report(w io.Writer) {
+	value := t.Value()
+	fmt.Fprintln(w, "Expected value: ", value[0], "player 2: ", value[1])
+	for _, infoSet := range t.InfoSets() {
+		fmt.Fprintf(w, "%8s: %v %v\n", infoSet, ActionNames(infoSet), t.NodeMap[infoSet].GetAvgStrategy())
+	}
+	fmt.Fprintln(w, "Num infosets: ", len(t.NodeMap))
+}
+
+// InfoSets returns every information set trained so far, round one first.
+func (t LeducTrainer) InfoSets() []string {
+	infoSets := make([]string, 0, len(t.NodeMap))
+	for infoSet := range t.NodeMap {
+		infoSets = append(infoSets, infoSet)
+	}
+	sort.Slice(infoSets, func(i, j int) bool {
+		return len(infoSets[i]) < len(infoSets[j]) || len(infoSets[i]) == len(infoSets[j]) && infoSets[i] < infoSets[j]
+	})
+	return infoSets
+}
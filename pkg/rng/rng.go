@@ -0,0 +1,50 @@
+// Package rng provides a math/rand source whose state can be saved and
+// restored, so sampled training runs can be checkpointed and resumed with
+// identical results.
+package rng
+
+import "math/rand"
+
+// Source is a seeded rand.Source64 that counts its draws. Its state is the
+// pair (seed, draws), and Restore rebuilds it by replaying that many draws.
+type Source struct {
+	seed  int64
+	draws uint64
+	src   rand.Source64
+}
+
+func NewSource(seed int64) *Source {
+	return &Source{
+		seed: seed,
+		src:  rand.NewSource(seed).(rand.Source64),
+	}
+}
+
+// Restore returns a source in the state State reported.
+func Restore(seed int64, draws uint64) *Source {
+	s := NewSource(seed)
+	for s.draws < draws {
+		s.Uint64()
+	}
+	return s
+}
+
+func (s *Source) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *Source) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *Source) Seed(seed int64) {
+	s.seed = seed
+	s.draws = 0
+	s.src.Seed(seed)
+}
+
+func (s *Source) State() (seed int64, draws uint64) {
+	return s.seed, s.draws
+}
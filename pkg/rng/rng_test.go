@@ -0,0 +1,23 @@
+package rng
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRestoreContinuesTheSequence(t *testing.T) {
+	src := NewSource(42)
+	r := rand.New(src)
+	for i := 0; i < 1000; i++ {
+		r.Shuffle(13, func(i, j int) {})
+		r.Float64()
+	}
+
+	seed, draws := src.State()
+	restored := rand.New(Restore(seed, draws))
+	for i := 0; i < 100; i++ {
+		if got, want := restored.Int63(), r.Int63(); got != want {
+			t.Fatalf("draw %d after restoring = %d, want %d", i, got, want)
+		}
+	}
+}
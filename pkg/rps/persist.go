@@ -0,0 +1,31 @@
+package rps
+
+import "github.com/pepperonirollz/cfr/pkg/snapshot"
+
+const snapshotGame = "rps"
+
+// Snapshot stores the trainer's sums as a single node with an empty
+// information set, since rps has only the one decision.
+func (t *RpsTrainer) Snapshot() *snapshot.Snapshot {
+	return snapshot.OneNode(snapshotGame, "", t.SnapshotNode())
+}
+
+func (t *RpsTrainer) Restore(s *snapshot.Snapshot) error {
+	n, err := s.OneNode(snapshotGame, "")
+	if err != nil {
+		return err
+	}
+	return t.RestoreNode(n)
+}
+
+func (t *RpsTrainer) Save(path string, f snapshot.Format) error {
+	return snapshot.Save(path, t.Snapshot(), f)
+}
+
+func (t *RpsTrainer) Load(path string) error {
+	s, err := snapshot.Load(path)
+	if err != nil {
+		return err
+	}
+	return t.Restore(s)
+}
@@ -0,0 +1,28 @@
+package rps
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/snapshot"
+)
+
+func TestSaveLoad(t *testing.T) {
+	trainer := NewRpsTrainer(WithSeed(1))
+	trainer.Train(1000)
+
+	for _, f := range []snapshot.Format{snapshot.JSON, snapshot.Binary} {
+		path := filepath.Join(t.TempDir(), "rps")
+		if err := trainer.Save(path, f); err != nil {
+			t.Fatal(err)
+		}
+		loaded := NewRpsTrainer()
+		if err := loaded.Load(path); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded.Snapshot(), trainer.Snapshot()) {
+			t.Errorf("format %d: loaded trainer differs from the saved one", f)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package rps
+
+import (
+	"strconv"
+
+	"github.com/pepperonirollz/cfr/pkg/game"
+)
+
+// RpsGame is rock paper scissors as a game.Game. Both players move in turn,
+// but the second player's information set does not reveal the first move, so
+// the tree is equivalent to the simultaneous game.
+type RpsGame struct {
+	NumActions int
+}
+
+type rpsState struct {
+	numActions int
+	actions    []int
+}
+
+func NewRpsGame() RpsGame {
+	return RpsGame{NumActions: 3}
+}
+
+func (g RpsGame) NumPlayers() int {
+	return 2
+}
+
+func (g RpsGame) Root() game.State {
+	return rpsState{numActions: g.NumActions}
+}
+
+func (s rpsState) IsTerminal() bool {
+	return len(s.actions) == 2
+}
+
+func (s rpsState) CurrentPlayer() int {
+	return len(s.actions)
+}
+
+func (s rpsState) LegalActions() []int {
+	actions := make([]int, s.numActions)
+	for a := range actions {
+		actions[a] = a
+	}
+	return actions
+}
+
+func (s rpsState) ChanceOutcomes() []game.Outcome {
+	return nil
+}
+
+func (s rpsState) Apply(action int) game.State {
+	return rpsState{
+		numActions: s.numActions,
+		actions:    append(append([]int(nil), s.actions...), action),
+	}
+}
+
+func (s rpsState) Utility(player int) float64 {
+	return utility(s.actions[player], s.actions[1-player], s.numActions)
+}
+
+func (s rpsState) InfoSetKey() string {
+	return strconv.Itoa(s.CurrentPlayer())
+}
+
+// utility follows the same cycle Train uses: each action beats the one
+// before it, so paper beats rock and rock beats scissors.
+func utility(myAction, otherAction, numActions int) float64 {
+	switch myAction {
+	case (otherAction + 1) % numActions:
+		return 1
+	case (otherAction + numActions - 1) % numActions:
+		return -1
+	default:
+		return 0
+	}
+}
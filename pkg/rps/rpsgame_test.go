@@ -0,0 +1,32 @@
+package rps
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
+)
+
+func TestRpsGameEquilibrium(t *testing.T) {
+	trainer := cfr.NewTrainer(NewRpsGame())
+	trainer.Train(1000)
+
+	for _, infoSet := range []string{"0", "1"} {
+		for a, p := range trainer.AvgStrategy(infoSet, 3) {
+			if math.Abs(p-1.0/3) > 0.01 {
+				t.Errorf("player %s plays action %d with %.3f, want 1/3", infoSet, a, p)
+			}
+		}
+	}
+}
+
+func TestExploitability(t *testing.T) {
+	uniform := func(string, int) []float64 { return []float64{1.0 / 3, 1.0 / 3, 1.0 / 3} }
+	if got := cfr.Exploitability(NewRpsGame(), uniform); math.Abs(got) > 1e-12 {
+		t.Errorf("uniform play is exploitable by %v", got)
+	}
+	rock := func(string, int) []float64 { return []float64{1, 0, 0} }
+	if got := cfr.Exploitability(NewRpsGame(), rock); got != 1 {
+		t.Errorf("always rock is exploitable by %v, want 1", got)
+	}
+}
@@ -3,58 +3,57 @@ package rps
 import (
 	"math/rand"
 	"time"
+
+	"github.com/pepperonirollz/cfr/pkg/cfr"
 )
 
 type RpsTrainer struct {
+	cfr.Node
 	Rock        int
 	Paper       int
 	Scissors    int
 	NumActions  int
-	Strategy    []float64
-	StrategySum []float64
-	RegretSum   []float64
 	OppStrategy []float64
+	rng         *rand.Rand
+}
+
+type Option func(*RpsTrainer)
+
+// WithSeed fixes the throws Train samples for both hands.
+func WithSeed(seed int64) Option {
+	return WithRand(rand.New(rand.NewSource(seed)))
+}
+
+// WithRand makes Train sample its throw and the opponent's from r.
+func WithRand(r *rand.Rand) Option {
+	return func(t *RpsTrainer) {
+		t.rng = r
+	}
 }
 
-func NewRpsTrainer() *RpsTrainer {
+func NewRpsTrainer(opts ...Option) *RpsTrainer {
 	numActions := 3
-	return &RpsTrainer{
+	t := &RpsTrainer{
+		Node:        *cfr.NewNode("", numActions),
 		Rock:        0,
 		Paper:       0,
 		Scissors:    0,
 		NumActions:  numActions,
-		Strategy:    make([]float64, numActions),
-		StrategySum: make([]float64, numActions),
-		RegretSum:   make([]float64, numActions),
 		OppStrategy: []float64{0.4, 0.4, 0.2},
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, opt := range opts {
+		opt(t)
 	}
+	return t
 }
 func (t *RpsTrainer) getStrategy() []float64 {
-	normalizingSum := 0.0
-	for i := 0; i < t.NumActions; i++ {
-		if t.RegretSum[i] > 0 {
-			t.Strategy[i] = t.RegretSum[i]
-		} else {
-			t.Strategy[i] = 0
-		}
-		normalizingSum += t.Strategy[i]
-	}
-
-	for i := 0; i < t.NumActions; i++ {
-		if normalizingSum > 0 {
-			t.Strategy[i] /= normalizingSum
-		} else {
-			t.Strategy[i] = 1.0 / float64(t.NumActions)
-		}
-		t.StrategySum[i] += t.Strategy[i]
-
-	}
+	t.RegretMatching()
+	t.AccumulateStrategy(1)
 	return t.Strategy
 }
 func (t *RpsTrainer) getAction(strategy []float64) int {
-	rand.Seed(time.Now().UnixNano())
-
-	r := rand.Float64()
+	r := t.rng.Float64()
 	a := 0
 	var cumulativeProbability float64 = 0
 	for a < t.NumActions-1 {
@@ -75,19 +74,8 @@ func (t *RpsTrainer) Train(iterations int) {
 		myAction := t.getAction(t.Strategy)
 		otherAction := t.getAction([]float64{0.35, 0.33, 0.32})
 
-		for j := range actionUtility {
-			actionUtility[j] = 0
-		}
-
-		if otherAction == t.NumActions-1 {
-			actionUtility[0] = 1
-		} else {
-			actionUtility[otherAction+1] = 1
-		}
-		if otherAction == 0 {
-			actionUtility[t.NumActions-1] = -1
-		} else {
-			actionUtility[otherAction-1] = -1
+		for a := range actionUtility {
+			actionUtility[a] = utility(a, otherAction, t.NumActions)
 		}
 
 		for a := 0; a < t.NumActions; a++ {
@@ -95,21 +83,13 @@ func (t *RpsTrainer) Train(iterations int) {
 		}
 	}
 }
-func (t *RpsTrainer) getAverageStrategy() []float64 {
-	avgStrategy := make([]float64, t.NumActions)
-	var normalizingSum float64
-
-	for _, sum := range t.StrategySum {
-		normalizingSum += sum
-	}
-
-	for a, sum := range t.StrategySum {
-		if normalizingSum > 0 {
-			avgStrategy[a] = sum / normalizingSum
-		} else {
-			avgStrategy[a] = 1.0 / float64(t.NumActions)
-		}
-	}
 
-	return avgStrategy
+// Exploitability is what a best response wins per game against the average
+// strategy, averaged over both seats. Train plays against a fixed opponent,
+// so the average strategy leans towards beating it rather than towards the
+// 1/3 equilibrium.
+func (t *RpsTrainer) Exploitability() float64 {
+	return cfr.Exploitability(RpsGame{NumActions: t.NumActions}, func(string, int) []float64 {
+		return t.GetAvgStrategy()
+	})
 }
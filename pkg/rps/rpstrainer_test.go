@@ -0,0 +1,34 @@
+package rps
+
+import "testing"
+
+// TestTrainPlaysPaper checks that against an opponent throwing rock and
+// paper 40% of the time each, the average strategy settles on paper.
+func TestTrainPlaysPaper(t *testing.T) {
+	r := NewRpsTrainer(WithSeed(1))
+	r.Train(100000)
+	if avg := r.GetAvgStrategy(); avg[1] < 0.9 {
+		t.Errorf("paper is played with probability %.3f, want at least 0.9", avg[1])
+	}
+}
+
+func BenchmarkTrain(b *testing.B) {
+	r := NewRpsTrainer(WithSeed(1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	r.Train(b.N)
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "iterations/s")
+}
+
+// BenchmarkConvergence trains a new trainer for a fixed budget per op and
+// reports the exploitability it reaches. Train plays a fixed opponent, so
+// this measures how far it drifts from the equilibrium.
+func BenchmarkConvergence(b *testing.B) {
+	var exploitability float64
+	for i := 0; i < b.N; i++ {
+		r := NewRpsTrainer(WithSeed(1))
+		r.Train(10000)
+		exploitability = r.Exploitability()
+	}
+	b.ReportMetric(exploitability, "exploitability")
+}
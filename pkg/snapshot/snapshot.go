@@ -0,0 +1,305 @@
+// Package snapshot is the on-disk format for trained strategies. A snapshot
+// holds every information set's cumulative regrets and strategy, so training
+// can be resumed from it as well as played from.
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// Version 2 added the random number generator state for checkpoints, and
+// version 3 the rules the strategy was trained for. Older snapshots are
+// still read, with what they lack left zero.
+const Version = 3
+
+type Format int
+
+const (
+	JSON Format = iota
+	// Binary is a compact little-endian encoding, about a quarter the size of
+	// JSON.
+	Binary
+)
+
+var ErrVersion = errors.New("snapshot: unsupported version")
+
+// magic starts every binary snapshot. JSON snapshots start with '{', which is
+// how Read tells the two apart.
+var magic = []byte("CFRS")
+
+type Snapshot struct {
+	Version int    `json:"version"`
+	Game    string `json:"game"`
+	// Config describes the rules of Game the nodes were trained for, in a
+	// form the game chooses, so they are not loaded into a trainer for
+	// another deck or stakes.
+	Config     string `json:"config,omitempty"`
+	Iterations int    `json:"iterations"`
+	// Seed and RNGDraws are the state of an rng.Source.
+	Seed     int64  `json:"seed"`
+	RNGDraws uint64 `json:"rngDraws"`
+	Nodes    []Node `json:"nodes"`
+}
+
+type Node struct {
+	InfoSet     string    `json:"infoSet"`
+	RegretSum   []float64 `json:"regretSum"`
+	StrategySum []float64 `json:"strategySum"`
+}
+
+// OneNode is a snapshot of a game with a single information set, such as
+// rps, trained for config.
+func OneNode(game, config string, n Node) *Snapshot {
+	return &Snapshot{
+		Version: Version,
+		Game:    game,
+		Config:  config,
+		Nodes:   []Node{n},
+	}
+}
+
+// OneNode returns the node of a snapshot made by OneNode for game and config.
+// Snapshots from before version 3 have no config to check.
+func (s *Snapshot) OneNode(game, config string) (Node, error) {
+	if s.Game != game {
+		return Node{}, fmt.Errorf("snapshot: written for %q, not %q", s.Game, game)
+	}
+	if s.Version >= 3 && s.Config != config {
+		return Node{}, fmt.Errorf("snapshot: written for %s, not %s", s.Config, config)
+	}
+	if len(s.Nodes) != 1 {
+		return Node{}, fmt.Errorf("snapshot: has %d nodes, not 1", len(s.Nodes))
+	}
+	return s.Nodes[0], nil
+}
+
+func Write(w io.Writer, s *Snapshot, f Format) error {
+	switch f {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", " ")
+		return enc.Encode(s)
+	case Binary:
+		return writeBinary(w, s)
+	default:
+		return fmt.Errorf("snapshot: unknown format %d", f)
+	}
+}
+
+func Read(r io.Reader) (*Snapshot, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(len(magic))
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	var s *Snapshot
+	if bytes.Equal(head, magic) {
+		s, err = readBinary(br)
+	} else {
+		s = &Snapshot{}
+		err = json.NewDecoder(br).Decode(s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	if s.Version < 1 || s.Version > Version {
+		return nil, fmt.Errorf("%w %d", ErrVersion, s.Version)
+	}
+	return s, nil
+}
+
+// Save writes s to path through a temporary file, so an interrupted save
+// never leaves a truncated snapshot behind.
+func Save(path string, s *Snapshot, f Format) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	w := bufio.NewWriter(tmp)
+	if err := Write(w, s, f); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func Load(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+func writeBinary(w io.Writer, s *Snapshot) error {
+	var buf bytes.Buffer
+	buf.Write(magic)
+	putUint(&buf, uint64(s.Version))
+	putString(&buf, s.Game)
+	putString(&buf, s.Config)
+	putUint(&buf, uint64(s.Iterations))
+	putInt(&buf, s.Seed)
+	putUint(&buf, s.RNGDraws)
+	putUint(&buf, uint64(len(s.Nodes)))
+	for _, node := range s.Nodes {
+		putString(&buf, node.InfoSet)
+		putFloats(&buf, node.RegretSum)
+		putFloats(&buf, node.StrategySum)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// readBinary reads the whole snapshot into memory first, so every length in
+// it can be checked against what is left before anything is allocated.
+func readBinary(r *bufio.Reader) (*Snapshot, error) {
+	if _, err := r.Discard(len(magic)); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	d := decoder{r: bytes.NewReader(data)}
+	s := &Snapshot{}
+	s.Version = int(d.uint())
+	if s.Version < 1 || s.Version > Version {
+		return s, d.err
+	}
+	s.Game = d.string()
+	if s.Version >= 3 {
+		s.Config = d.string()
+	}
+	s.Iterations = int(d.uint())
+	if s.Version >= 2 {
+		s.Seed = d.int()
+		s.RNGDraws = d.uint()
+	}
+	// every node takes at least a byte for each of its three lengths
+	s.Nodes = make([]Node, d.length(3))
+	for i := range s.Nodes {
+		if d.err != nil {
+			break
+		}
+		s.Nodes[i].InfoSet = d.string()
+		s.Nodes[i].RegretSum = d.floats()
+		s.Nodes[i].StrategySum = d.floats()
+	}
+	return s, d.err
+}
+
+func putUint(buf *bytes.Buffer, v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func putInt(buf *bytes.Buffer, v int64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func putString(buf *bytes.Buffer, s string) {
+	putUint(buf, uint64(len(s)))
+	buf.WriteString(s)
+}
+
+func putFloats(buf *bytes.Buffer, values []float64) {
+	putUint(buf, uint64(len(values)))
+	var b [8]byte
+	for _, v := range values {
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+		buf.Write(b[:])
+	}
+}
+
+var errLength = errors.New("length runs past the end of the snapshot")
+
+// decoder reads binary fields until the first error, which it keeps.
+type decoder struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (d *decoder) uint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	var v uint64
+	v, d.err = binary.ReadUvarint(d.r)
+	return v
+}
+
+func (d *decoder) int() int64 {
+	if d.err != nil {
+		return 0
+	}
+	var v int64
+	v, d.err = binary.ReadVarint(d.r)
+	return v
+}
+
+// length reads the number of items that follow, each taking at least size
+// bytes, and fails if they cannot fit in what is left.
+func (d *decoder) length(size int) int {
+	n := d.uint()
+	if d.err != nil {
+		return 0
+	}
+	if n > uint64(d.r.Len()/size) {
+		d.err = errLength
+		return 0
+	}
+	return int(n)
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.length(1)
+	if d.err != nil {
+		return nil
+	}
+	b := make([]byte, n)
+	_, d.err = io.ReadFull(d.r, b)
+	return b
+}
+
+func (d *decoder) string() string {
+	return string(d.bytes())
+}
+
+func (d *decoder) floats() []float64 {
+	n := d.length(8)
+	if d.err != nil {
+		return nil
+	}
+	values := make([]float64, n)
+	var b [8]byte
+	for i := range values {
+		if _, d.err = io.ReadFull(d.r, b[:]); d.err != nil {
+			return nil
+		}
+		values[i] = math.Float64frombits(binary.LittleEndian.Uint64(b[:]))
+	}
+	return values
+}
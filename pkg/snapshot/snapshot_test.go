@@ -0,0 +1,107 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	want := &Snapshot{
+		Version:    Version,
+		Game:       "kuhn",
+		Config:     "deck JQK ante 1 bet 1 players 2",
+		Iterations: 12345,
+		Seed:       -7,
+		RNGDraws:   1 << 40,
+		Nodes: []Node{
+			{InfoSet: "0 K", RegretSum: []float64{-1.5, 1e-300}, StrategySum: []float64{math.Pi, 0}},
+			{InfoSet: "1 Jpb", RegretSum: []float64{0, 0}, StrategySum: []float64{1, 2}},
+		},
+	}
+	for _, f := range []Format{JSON, Binary} {
+		var buf bytes.Buffer
+		if err := Write(&buf, want, f); err != nil {
+			t.Fatalf("format %d: %v", f, err)
+		}
+		got, err := Read(&buf)
+		if err != nil {
+			t.Fatalf("format %d: %v", f, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("format %d: read %+v, want %+v", f, got, want)
+		}
+	}
+}
+
+func TestReadRejectsOtherVersions(t *testing.T) {
+	for _, f := range []Format{JSON, Binary} {
+		var buf bytes.Buffer
+		if err := Write(&buf, &Snapshot{Version: Version + 1, Game: "kuhn"}, f); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Read(&buf); !errors.Is(err, ErrVersion) {
+			t.Errorf("format %d: err = %v, want ErrVersion", f, err)
+		}
+	}
+}
+
+func TestReadVersion1(t *testing.T) {
+	v1 := `{"version": 1, "game": "rps", "iterations": 3, "nodes": [{"infoSet": "", "regretSum": [1, 2, 3], "strategySum": [3, 2, 1]}]}`
+	s, err := Read(strings.NewReader(v1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Game != "rps" || s.Iterations != 3 || s.Seed != 0 || s.RNGDraws != 0 || len(s.Nodes) != 1 {
+		t.Errorf("read %+v", s)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(magic)
+	putUint(&buf, 1)
+	putString(&buf, "rps")
+	putUint(&buf, 3)
+	putUint(&buf, 1)
+	putString(&buf, "")
+	putFloats(&buf, []float64{1, 2, 3})
+	putFloats(&buf, []float64{3, 2, 1})
+	if s, err = Read(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if s.Game != "rps" || s.Iterations != 3 || len(s.Nodes) != 1 || s.Nodes[0].StrategySum[0] != 3 {
+		t.Errorf("read %+v", s)
+	}
+}
+
+func TestReadTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	Write(&buf, &Snapshot{Version: Version, Game: "kuhn", Nodes: []Node{{InfoSet: "0 K", RegretSum: []float64{1, 2}}}}, Binary)
+	truncated := strings.NewReader(buf.String()[:buf.Len()-3])
+	if _, err := Read(truncated); err == nil {
+		t.Error("read a truncated snapshot without error")
+	}
+}
+
+func TestReadCorrupt(t *testing.T) {
+	for name, write := range map[string]func(*bytes.Buffer){
+		"nodes":   func(buf *bytes.Buffer) { putUint(buf, math.MaxInt64) },
+		"infoSet": func(buf *bytes.Buffer) { putUint(buf, 1); putUint(buf, 1<<62) },
+		"floats":  func(buf *bytes.Buffer) { putUint(buf, 1); putString(buf, "0 K"); putUint(buf, 1<<61) },
+	} {
+		var buf bytes.Buffer
+		buf.Write(magic)
+		putUint(&buf, Version)
+		putString(&buf, "kuhn")
+		putString(&buf, "")
+		putUint(&buf, 0)
+		putInt(&buf, 0)
+		putUint(&buf, 0)
+		write(&buf)
+		if _, err := Read(&buf); err == nil {
+			t.Errorf("%s: read a snapshot with an impossible length without error", name)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Renders the average strategy RoboDurrr plays the current deck with, from
+// /api/strategy, as a grid with a row per card and a column per decision
+// point, and highlights the decision point the AI last acted at.
+
+const decisionNames = {
+    "0 ": "Player 1 opens",
+    "1 p": "Player 2 after a check",
+    "1 b": "Player 2 facing a bet",
+    "0 pb": "Player 1 facing a bet after checking",
+};
+
+function decisionKey(s) {
+    return `${s.player} ${s.history}`;
+}
+
+function renderActionTree(decisions) {
+    const tree = document.getElementById("actionTree");
+    tree.innerHTML = "";
+    const list = document.createElement("ol");
+    for (const key of decisions) {
+        const item = document.createElement("li");
+        item.dataset.decision = key;
+        item.textContent = decisionNames[key] || key;
+        list.append(item);
+    }
+    tree.append(list);
+}
+
+function renderProbabilityGrid(strategies) {
+    const decisions = [];
+    const cards = [];
+    const bets = {};
+    for (const s of strategies) {
+        const key = decisionKey(s);
+        if (!decisions.includes(key)) {
+            decisions.push(key);
+        }
+        if (!cards.includes(s.card)) {
+            cards.push(s.card);
+        }
+        bets[`${key}|${s.card}`] = s.strategy[1];
+    }
+
+    const table = document.createElement("table");
+    const header = table.insertRow();
+    header.insertCell().textContent = "Card";
+    for (const key of decisions) {
+        const cell = header.insertCell();
+        cell.dataset.decision = key;
+        cell.textContent = decisionNames[key] || key;
+    }
+    for (const card of cards) {
+        const row = table.insertRow();
+        row.insertCell().textContent = card;
+        for (const key of decisions) {
+            const cell = row.insertCell();
+            cell.dataset.decision = key;
+            const bet = bets[`${key}|${card}`];
+            if (bet === undefined) {
+                continue;
+            }
+            cell.textContent = `${Math.round(bet * 100)}%`;
+            cell.title = `${card}, ${decisionNames[key] || key}: pass ${Math.round((1 - bet) * 100)}%, bet ${Math.round(bet * 100)}%`;
+            cell.style.backgroundColor = `rgba(0, 128, 0, ${bet})`;
+        }
+    }
+    const grid = document.getElementById("probabilityGrid");
+    grid.innerHTML = "";
+    grid.append(table);
+    renderActionTree(decisions);
+}
+
+function highlightDecision(key) {
+    for (const el of document.querySelectorAll("[data-decision]")) {
+        el.classList.toggle("highlight", el.dataset.decision === key);
+    }
+}
+
+function highlightFromDashboard() {
+    const dash = document.getElementById("dash");
+    if (dash) {
+        highlightDecision(dash.dataset.aiDecision);
+    }
+}
+
+let shownDeck;
+
+// showStrategy renders the strategy of the AI for deck, unless it is already
+// shown, and highlights its latest decision.
+function showStrategy(deck) {
+    if (deck === shownDeck) {
+        highlightFromDashboard();
+        return;
+    }
+    shownDeck = deck;
+    fetch(`/api/strategy?deck=${encodeURIComponent(deck)}`)
+        .then((res) => res.json())
+        .then((body) => {
+            renderProbabilityGrid(body.infoSets);
+            highlightFromDashboard();
+        });
+}
+
+function showDashboardStrategy() {
+    const dash = document.getElementById("dash");
+    showStrategy((dash && dash.dataset.deck) || "full");
+}
+
+showDashboardStrategy();
+
+document.body.addEventListener("htmx:afterSwap", showDashboardStrategy);
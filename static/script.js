@@ -0,0 +1,43 @@
+// Live events for the game started with the button above. The stream is
+// reopened whenever a new game is started.
+let events = null;
+
+function describe(e) {
+    const who = e.actor === "ai" ? "RoboDurrr" : "You";
+    switch (e.type) {
+    case "deal":
+        return `Hand ${e.hand}: you are dealt a ${e.playerCard}`;
+    case "action":
+        return `${who} ${e.action}${e.amount ? " " + e.amount : ""}`;
+    case "showdown":
+        return `Showdown: your ${e.playerCard} against RoboDurrr's ${e.aiCard}`;
+    case "stack":
+        return `Stacks: you ${e.playerStack}, RoboDurrr ${e.aiStack}, pot ${e.pot}`;
+    case "matchEnd":
+        return `Match over: you ${e.playerStack}, RoboDurrr ${e.aiStack}`;
+    }
+    return e.type;
+}
+
+function watch() {
+    if (events) {
+        events.close();
+    }
+    const list = document.getElementById("events");
+    list.innerHTML = "";
+    events = new EventSource("/events");
+    for (const type of ["deal", "action", "showdown", "stack", "matchEnd"]) {
+        events.addEventListener(type, (msg) => {
+            const item = document.createElement("li");
+            item.className = "event-" + type;
+            item.textContent = describe(JSON.parse(msg.data));
+            list.prepend(item);
+        });
+    }
+}
+
+document.body.addEventListener("htmx:afterRequest", (evt) => {
+    if (evt.detail.pathInfo.requestPath === "/start" && evt.detail.successful) {
+        watch();
+    }
+});